@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"errors"
+	"github.com/donknap/dpanel/app/application/logic"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/we7coreteam/w7-rangine-go/v2/src/http/controller"
+	"time"
+)
+
+type Alert struct {
+	controller.Abstract
+}
+
+func (self Alert) Create(http *gin.Context) {
+	type ParamsValidate struct {
+		Id         int32                      `json:"id"`
+		Title      string                     `json:"title" binding:"required"`
+		Enable     bool                       `json:"enable"`
+		Metric     string                     `json:"metric" binding:"required,oneof=cpu memory"`
+		Comparator string                     `json:"comparator" binding:"required,oneof=gt gte lt lte"`
+		Threshold  float64                    `json:"threshold" binding:"gte=0"`
+		Duration   int                        `json:"duration" binding:"gte=0"`
+		Selector   accessor.AlertSelectorItem `json:"selector"`
+		Webhook    []string                   `json:"webhook"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Selector.Type == "" {
+		params.Selector.Type = logic.AlertSelectorName
+	}
+	if params.Selector.Type != logic.AlertSelectorName && params.Selector.Value == "" {
+		self.JsonResponseWithError(http, errors.New("请填写告警规则的匹配条件"), 500)
+		return
+	}
+
+	ruleNew := &entity.AlertRule{
+		Title:  params.Title,
+		Enable: params.Enable,
+		Setting: &accessor.AlertRuleSettingOption{
+			Metric:     params.Metric,
+			Comparator: params.Comparator,
+			Threshold:  params.Threshold,
+			Duration:   params.Duration,
+			Selector:   params.Selector,
+			Webhook:    params.Webhook,
+		},
+	}
+
+	var err error
+	if params.Id <= 0 {
+		ruleNew.CreatedAt = time.Now().Local()
+		err = dao.AlertRule.Create(ruleNew)
+	} else {
+		ruleRow, _ := dao.AlertRule.Where(dao.AlertRule.ID.Eq(params.Id)).First()
+		if ruleRow == nil {
+			self.JsonResponseWithError(http, errors.New("告警规则不存在"), 500)
+			return
+		}
+		// 编辑规则时保留已经设置的静默时段
+		if ruleRow.Setting != nil {
+			ruleNew.Setting.Silence = ruleRow.Setting.Silence
+		}
+		_, err = dao.AlertRule.Where(dao.AlertRule.ID.Eq(params.Id)).
+			Select(dao.AlertRule.Title, dao.AlertRule.Enable, dao.AlertRule.Setting).
+			Updates(ruleNew)
+		ruleNew.ID = params.Id
+	}
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"id": ruleNew.ID,
+	})
+	return
+}
+
+func (self Alert) GetList(http *gin.Context) {
+	type ParamsValidate struct {
+		Title string `json:"title"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	query := dao.AlertRule.Order(dao.AlertRule.ID.Desc())
+	if params.Title != "" {
+		query = query.Where(dao.AlertRule.Title.Like("%" + params.Title + "%"))
+	}
+	list, _ := query.Find()
+	self.JsonResponseWithoutError(http, gin.H{
+		"list": list,
+	})
+	return
+}
+
+func (self Alert) Delete(http *gin.Context) {
+	type ParamsValidate struct {
+		Id []int32 `json:"id" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	_, err := dao.AlertRule.Where(dao.AlertRule.ID.In(params.Id...)).Delete()
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonSuccessResponse(http)
+	return
+}
+
+func (self Alert) Silence(http *gin.Context) {
+	type ParamsValidate struct {
+		Id       int32 `json:"id" binding:"required"`
+		Duration int   `json:"duration"` // 静默多少分钟，为 0 时清除所有静默时段
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	ruleRow, _ := dao.AlertRule.Where(dao.AlertRule.ID.Eq(params.Id)).First()
+	if ruleRow == nil || ruleRow.Setting == nil {
+		self.JsonResponseWithError(http, errors.New("告警规则不存在"), 500)
+		return
+	}
+	now := time.Now().Local()
+	silence := make([]accessor.AlertSilenceItem, 0)
+	if params.Duration > 0 {
+		// 清理掉已经过期的静默时段
+		for _, item := range ruleRow.Setting.Silence {
+			if item.EndAt.After(now) {
+				silence = append(silence, item)
+			}
+		}
+		silence = append(silence, accessor.AlertSilenceItem{
+			StartAt: now,
+			EndAt:   now.Add(time.Minute * time.Duration(params.Duration)),
+		})
+	}
+	ruleRow.Setting.Silence = silence
+	_, err := dao.AlertRule.Where(dao.AlertRule.ID.Eq(params.Id)).Updates(&entity.AlertRule{
+		Setting: ruleRow.Setting,
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonSuccessResponse(http)
+	return
+}
+
+func (self Alert) GetFiringList(http *gin.Context) {
+	self.JsonResponseWithoutError(http, gin.H{
+		"list": logic.Alert{}.GetFiringList(),
+	})
+	return
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+	"github.com/gin-gonic/gin"
+)
+
+func (self Container) Batch(http *gin.Context) {
+	type ParamsValidate struct {
+		TaskId       string   `json:"taskId"`
+		Operate      string   `json:"operate" binding:"required,oneof=start stop restart pause unpause delete upgrade"`
+		Md5          []string `json:"md5"`
+		Name         string   `json:"name"`
+		Label        string   `json:"label"`
+		Compose      string   `json:"compose"`
+		Worker       int      `json:"worker" binding:"omitempty,gte=1,lte=20"`
+		DeleteVolume bool     `json:"deleteVolume"`
+		ConfirmToken string   `json:"confirmToken"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	result, err := logic.DockerTask{}.ContainerBatch(&logic.ContainerBatchOption{
+		TaskId:       params.TaskId,
+		Operate:      params.Operate,
+		Md5:          params.Md5,
+		Name:         params.Name,
+		Label:        params.Label,
+		Compose:      params.Compose,
+		Worker:       params.Worker,
+		DeleteVolume: params.DeleteVolume,
+		ConfirmToken: params.ConfirmToken,
+		Username:     commonLogic.User{}.GetUsernameByContext(http),
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, deleteConfirmErrorCode(err))
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
+
+func (self Container) GetBatchList(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5     []string `json:"md5"`
+		Name    string   `json:"name"`
+		Label   string   `json:"label"`
+		Compose string   `json:"compose"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	list, err := logic.DockerTask{}.ContainerBatchSelect(&logic.ContainerBatchOption{
+		Md5:     params.Md5,
+		Name:    params.Name,
+		Label:   params.Label,
+		Compose: params.Compose,
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"list": list,
+	})
+	return
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"errors"
+	"github.com/docker/docker/api/types/container"
+	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/service/docker"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+func (self Container) Commit(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5      string   `json:"md5" binding:"required"`
+		Tag      string   `json:"tag" binding:"required"`
+		Registry string   `json:"registry"`
+		Title    string   `json:"title"`
+		Author   string   `json:"author"`
+		Message  string   `json:"message"`
+		Pause    *bool    `json:"pause"` // 不传时默认暂停容器，与 docker commit 一致
+		Cmd      string   `json:"cmd"`
+		WorkDir  string   `json:"workDir"`
+		Expose   []string `json:"expose"`
+		Env      []string `json:"env"`
+		Volume   []string `json:"volume"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	imageName := logic.Image{}.GetImageName(&logic.ImageNameOption{
+		Registry: params.Registry,
+		Name:     params.Tag,
+	})
+	imageInfo, _, _ := docker.Sdk.Client.ImageInspectWithRaw(docker.Sdk.Ctx, imageName)
+	if imageInfo.ID != "" {
+		self.JsonResponseWithError(http, errors.New("镜像名称已经存在"), 500)
+		return
+	}
+	if params.Author == "" {
+		params.Author = commonLogic.User{}.GetUsernameByContext(http)
+	}
+
+	// 与 Dockerfile 中的指令格式相同
+	change := make([]string, 0)
+	if params.Cmd != "" {
+		change = append(change, "CMD "+params.Cmd)
+	}
+	if params.WorkDir != "" {
+		change = append(change, "WORKDIR "+params.WorkDir)
+	}
+	for _, port := range params.Expose {
+		change = append(change, "EXPOSE "+port)
+	}
+	for _, env := range params.Env {
+		change = append(change, "ENV "+env)
+	}
+	for _, volume := range params.Volume {
+		change = append(change, "VOLUME "+volume)
+	}
+
+	response, err := docker.Sdk.Client.ContainerCommit(docker.Sdk.Ctx, params.Md5, container.CommitOptions{
+		Reference: imageName,
+		Comment:   params.Message,
+		Author:    params.Author,
+		Changes:   change,
+		Pause:     params.Pause == nil || *params.Pause,
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+
+	imageNew := &entity.Image{
+		Tag:   imageName,
+		Title: params.Title,
+		Setting: &accessor.ImageSettingOption{
+			Registry:        params.Registry,
+			BuildDockerfile: strings.Join(change, "\n"),
+		},
+		ImageInfo: &accessor.ImageInfoOption{
+			Id: imageName,
+		},
+		BuildType: "commit",
+		Status:    logic.StatusSuccess,
+		Message:   params.Message,
+	}
+	imageRow, _ := dao.Image.Where(dao.Image.Tag.Eq(imageName)).First()
+	if imageRow == nil {
+		_ = dao.Image.Create(imageNew)
+	} else {
+		imageNew.ID = imageRow.ID
+		_, _ = dao.Image.Select(
+			dao.Image.Title,
+			dao.Image.Setting,
+			dao.Image.ImageInfo,
+			dao.Image.BuildType,
+			dao.Image.Status,
+			dao.Image.Message,
+		).Where(dao.Image.ID.Eq(imageRow.ID)).Updates(imageNew)
+	}
+
+	self.JsonResponseWithoutError(http, gin.H{
+		"imageId": imageNew.ID,
+		"md5":     response.ID,
+		"tag":     imageName,
+	})
+	return
+}
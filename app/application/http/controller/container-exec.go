@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/gin-gonic/gin"
+)
+
+func (self Container) Exec(http *gin.Context) {
+	type ParamsValidate struct {
+		TaskId  string   `json:"taskId"`
+		Md5     []string `json:"md5"`
+		Name    string   `json:"name"`
+		Label   string   `json:"label"`
+		Compose string   `json:"compose"`
+		Cmd     string   `json:"cmd" binding:"required"`
+		Shell   bool     `json:"shell"`
+		Env     []string `json:"env"`
+		WorkDir string   `json:"workDir"`
+		User    string   `json:"user"`
+		Timeout int      `json:"timeout" binding:"omitempty,gte=1,lte=3600"`
+		Worker  int      `json:"worker" binding:"omitempty,gte=1,lte=20"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	result, err := logic.DockerTask{}.ContainerExec(&logic.ContainerExecOption{
+		TaskId:   params.TaskId,
+		Md5:      params.Md5,
+		Name:     params.Name,
+		Label:    params.Label,
+		Compose:  params.Compose,
+		Cmd:      params.Cmd,
+		Shell:    params.Shell,
+		Env:      params.Env,
+		WorkDir:  params.WorkDir,
+		User:     params.User,
+		Timeout:  params.Timeout,
+		Worker:   params.Worker,
+		Username: commonLogic.User{}.GetUsernameByContext(http),
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
+
+func (self Container) GetExecList(http *gin.Context) {
+	type ParamsValidate struct {
+		TaskId        string `json:"taskId"`
+		ContainerName string `json:"containerName"`
+		Status        string `json:"status"`
+		Page          int    `json:"page,default=1" binding:"omitempty,gt=0"`
+		PageSize      int    `json:"pageSize" binding:"omitempty,gt=1"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+	query := dao.ContainerExec.Order(dao.ContainerExec.ID.Desc())
+	if params.TaskId != "" {
+		query = query.Where(dao.ContainerExec.TaskID.Eq(params.TaskId))
+	}
+	if params.ContainerName != "" {
+		query = query.Where(dao.ContainerExec.ContainerName.Like("%" + params.ContainerName + "%"))
+	}
+	if params.Status != "" {
+		query = query.Where(dao.ContainerExec.Status.Eq(params.Status))
+	}
+	list, total, _ := query.FindByPage((params.Page-1)*params.PageSize, params.PageSize)
+	self.JsonResponseWithoutError(http, gin.H{
+		"total": total,
+		"page":  params.Page,
+		"list":  list,
+	})
+	return
+}
+
+func (self Container) DeleteExec(http *gin.Context) {
+	type ParamsValidate struct {
+		Id []int32 `json:"id" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	_, err := dao.ContainerExec.Where(dao.ContainerExec.ID.In(params.Id...)).Delete()
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonSuccessResponse(http)
+	return
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"github.com/donknap/dpanel/app/application/logic"
+	"github.com/gin-gonic/gin"
+)
+
+// GetGraph 获取容器与网络、存储卷、compose 之间的关系图
+// 指定 md5 时只返回该容器周围的节点，并给出删除后会受影响的容器
+func (self Container) GetGraph(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5   string `json:"md5"`
+		Depth int    `json:"depth" binding:"omitempty,gte=0,lte=5"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	result, err := logic.NewContainerGraph().Build(&logic.ContainerGraphOption{
+		Md5:   params.Md5,
+		Depth: params.Depth,
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
@@ -5,10 +5,8 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/api/types/image"
-	"github.com/docker/docker/api/types/mount"
-	"github.com/docker/docker/api/types/network"
 	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
 	"github.com/donknap/dpanel/common/accessor"
 	"github.com/donknap/dpanel/common/dao"
 	"github.com/donknap/dpanel/common/function"
@@ -16,9 +14,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/we7coreteam/w7-rangine-go/v2/src/http/controller"
 	"io"
-	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Container struct {
@@ -152,10 +150,16 @@ func (self Container) GetList(http *gin.Context) {
 	siteList, _ := query.Find()
 
 	domainList, _ := dao.SiteDomain.Where(dao.SiteDomain.ContainerID.In(nameList...)).Find()
+
+	imageList := make([]string, 0)
+	for _, item := range result {
+		imageList = append(imageList, item.Image)
+	}
 	self.JsonResponseWithoutError(http, gin.H{
-		"list":       result,
-		"siteList":   siteList,
-		"domainList": domainList,
+		"list":        result,
+		"siteList":    siteList,
+		"domainList":  domainList,
+		"upgradeList": logic.ImageUpdate{}.GetListByTag(imageList...),
 	})
 	return
 }
@@ -216,97 +220,132 @@ func (self Container) Update(http *gin.Context) {
 	return
 }
 
-func (self Container) Prune(http *gin.Context) {
-	filter := filters.NewArgs()
-	docker.Sdk.Client.ContainersPrune(docker.Sdk.Ctx, filter)
-	self.JsonSuccessResponse(http)
+func (self Container) GetRightsizingList(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5  string `json:"md5"`
+		Days int    `json:"days"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Days <= 0 {
+		params.Days = 7
+	}
+	list, err := logic.Rightsizing{}.GetSuggestion(params.Md5, time.Now().Local().AddDate(0, 0, -params.Days))
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"list": list,
+	})
 	return
 }
 
-func (self Container) Delete(http *gin.Context) {
+func (self Container) ApplyRightsizing(http *gin.Context) {
 	type ParamsValidate struct {
-		Md5          string `json:"md5" binding:"required"`
-		DeleteImage  bool   `json:"deleteImage" binding:"omitempty"`
-		DeleteVolume bool   `json:"deleteVolume" binding:"omitempty"`
-		DeleteLink   bool   `json:"deleteLink" binding:"omitempty"`
+		Md5    string  `json:"md5" binding:"required"`
+		Cpus   float32 `json:"cpus" binding:"omitempty,gte=0"`
+		Memory int     `json:"memory" binding:"omitempty,gte=0"`
 	}
 	params := ParamsValidate{}
 	if !self.Validate(http, &params) {
 		return
 	}
-	var err error
-	containerInfo, err := docker.Sdk.ContainerInfo(params.Md5)
+	err := logic.Rightsizing{}.Apply(params.Md5, params.Cpus, params.Memory, commonLogic.User{}.GetUsernameByContext(http))
 	if err != nil {
 		self.JsonResponseWithError(http, err, 500)
 		return
 	}
-	siteRow, _ := dao.Site.Where(dao.Site.ContainerInfo.Eq(&accessor.SiteContainerInfoOption{
-		ID: params.Md5,
-	})).First()
-
-	if siteRow != nil && siteRow.SiteName != "" {
-		// 删除网络
-		// 获取该容器的网络，退出里面的容器
-		networkInfo, err := docker.Sdk.Client.NetworkInspect(docker.Sdk.Ctx, siteRow.SiteName, network.InspectOptions{})
-		if err == nil {
-			for md5, _ := range networkInfo.Containers {
-				err = docker.Sdk.Client.NetworkDisconnect(docker.Sdk.Ctx, siteRow.SiteName, md5, true)
-				if err != nil {
-					self.JsonResponseWithError(http, err, 500)
-					return
-				}
+	self.JsonSuccessResponse(http)
+	return
+}
 
-			}
-			err = docker.Sdk.Client.NetworkRemove(docker.Sdk.Ctx, siteRow.SiteName)
-			if err != nil {
-				self.JsonResponseWithError(http, err, 500)
-				return
-			}
-		}
+func (self Container) Upgrade(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5      string `json:"md5" binding:"required"`
+		Force    bool   `json:"force"`
+		KeepOld  bool   `json:"keepOld"`
+		Platform string `json:"platform"`
 	}
-
-	// 删除域名、配置、证书
-	domainList, _ := dao.SiteDomain.Where(dao.SiteDomain.ContainerID.Eq(containerInfo.Name)).Find()
-	for _, domain := range domainList {
-		logic.Site{}.GetSiteNginxSetting(domain.ServerName).RemoveAll()
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
 	}
-	dao.SiteDomain.Where(dao.SiteDomain.ContainerID.Eq(containerInfo.ID)).Delete()
-
-	err = docker.Sdk.Client.ContainerStop(docker.Sdk.Ctx, containerInfo.ID, container.StopOptions{})
+	result, err := logic.DockerTask{}.ContainerUpgrade(&logic.ContainerUpgradeOption{
+		Md5:      params.Md5,
+		Force:    params.Force,
+		KeepOld:  params.KeepOld,
+		Platform: params.Platform,
+		Username: commonLogic.User{}.GetUsernameByContext(http),
+	})
 	if err != nil {
 		self.JsonResponseWithError(http, err, 500)
 		return
 	}
-	err = docker.Sdk.Client.ContainerRemove(docker.Sdk.Ctx, containerInfo.ID, container.RemoveOptions{
-		RemoveVolumes: params.DeleteVolume,
-		RemoveLinks:   params.DeleteLink,
+	self.JsonResponseWithoutError(http, gin.H{
+		"result": result,
 	})
+	return
+}
+
+func (self Container) CheckUpgrade(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5 []string `json:"md5"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	list, err := logic.ImageUpdate{}.Check(params.Md5...)
 	if err != nil {
 		self.JsonResponseWithError(http, err, 500)
 		return
 	}
-	if params.DeleteImage {
-		_, err = docker.Sdk.Client.ImageRemove(docker.Sdk.Ctx, containerInfo.Image, image.RemoveOptions{
-			Force:         true,
-			PruneChildren: true,
-		})
-	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"list": list,
+	})
+	return
+}
 
-	if params.DeleteVolume {
-		for _, item := range containerInfo.Mounts {
-			if item.Type == mount.TypeVolume {
-				err = docker.Sdk.Client.VolumeRemove(docker.Sdk.Ctx, item.Name, false)
-				if err != nil {
-					slog.Debug("remove container volume", err.Error())
-				}
-			}
-		}
-	}
+func (self Container) Prune(http *gin.Context) {
+	filter := filters.NewArgs()
+	docker.Sdk.Client.ContainersPrune(docker.Sdk.Ctx, filter)
+	self.JsonSuccessResponse(http)
+	return
+}
 
-	if siteRow != nil {
-		dao.Site.Where(dao.Site.ID.Eq(siteRow.ID)).Delete()
+func (self Container) Delete(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5          string `json:"md5" binding:"required"`
+		DeleteImage  bool   `json:"deleteImage" binding:"omitempty"`
+		DeleteVolume bool   `json:"deleteVolume" binding:"omitempty"`
+		DeleteLink   bool   `json:"deleteLink" binding:"omitempty"`
+		ConfirmToken string `json:"confirmToken"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	err := logic.DeleteImpact{}.Confirm(logic.DeleteTargetContainer, []string{params.Md5}, params.ConfirmToken)
+	if err != nil {
+		self.JsonResponseWithError(http, err, deleteConfirmErrorCode(err))
+		return
+	}
+	siteId, err := logic.DockerTask{}.ContainerDelete(&logic.ContainerDeleteOption{
+		Md5:          params.Md5,
+		DeleteImage:  params.DeleteImage,
+		DeleteVolume: params.DeleteVolume,
+		DeleteLink:   params.DeleteLink,
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	if siteId != 0 {
 		self.JsonResponseWithoutError(http, gin.H{
-			"siteId": siteRow.ID,
+			"siteId": siteId,
 			"md5":    params.Md5,
 		})
 	} else {
@@ -343,3 +382,53 @@ func (self Container) Export(http *gin.Context) {
 	http.Data(200, "application/tar", data)
 	return
 }
+
+func (self Container) ExportConfig(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5 string `json:"md5" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	result, err := logic.Site{}.ExportConfig(params.Md5)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
+
+func (self Container) Clone(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5         string `json:"md5" binding:"required"`
+		SiteName    string `json:"siteName" binding:"required"`
+		SiteTitle   string `json:"siteTitle"`
+		ReplacePort bool   `json:"replacePort"`
+		CopyVolume  bool   `json:"copyVolume"`
+		Network     string `json:"network" binding:"omitempty,oneof=share isolate"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.SiteTitle == "" {
+		params.SiteTitle = params.SiteName
+	}
+	result, err := logic.DockerTask{}.ContainerClone(&logic.ContainerCloneOption{
+		Md5:         params.Md5,
+		SiteName:    params.SiteName,
+		SiteTitle:   params.SiteTitle,
+		ReplacePort: params.ReplacePort,
+		CopyVolume:  params.CopyVolume,
+		Network:     params.Network,
+		Username:    commonLogic.User{}.GetUsernameByContext(http),
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
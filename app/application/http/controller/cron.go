@@ -0,0 +1,270 @@
+package controller
+
+import (
+	"errors"
+	"github.com/donknap/dpanel/app/application/logic"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
+	"github.com/donknap/dpanel/common/service/cron"
+	"github.com/gin-gonic/gin"
+	"github.com/we7coreteam/w7-rangine-go/v2/src/http/controller"
+	"time"
+)
+
+type Cron struct {
+	controller.Abstract
+}
+
+func (self Cron) Create(http *gin.Context) {
+	type ParamsValidate struct {
+		Id                int32    `json:"id"`
+		Title             string   `json:"title" binding:"required"`
+		Expression        string   `json:"expression" binding:"required"`
+		Enable            bool     `json:"enable"`
+		Type              string   `json:"type" binding:"required,oneof=containerStart containerStop containerRestart containerExec imagePull prune composeDeploy"`
+		ContainerName     string   `json:"containerName"`
+		Command           string   `json:"command"`
+		WorkDir           string   `json:"workDir"`
+		ImageName         string   `json:"imageName"`
+		PruneTarget       []string `json:"pruneTarget"`
+		ComposeId         int32    `json:"composeId"`
+		Timeout           int      `json:"timeout" binding:"omitempty,gte=0"`
+		ConcurrencyPolicy string   `json:"concurrencyPolicy" binding:"omitempty,oneof=skip allow"`
+		KeepLogs          int      `json:"keepLogs" binding:"omitempty,gte=1,lte=1000"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if _, err := cron.Parse(params.Expression); err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	switch params.Type {
+	case logic.CronTypeContainerStart, logic.CronTypeContainerStop, logic.CronTypeContainerRestart:
+		if params.ContainerName == "" {
+			self.JsonResponseWithError(http, errors.New("请选择容器"), 500)
+			return
+		}
+	case logic.CronTypeContainerExec:
+		if params.ContainerName == "" || params.Command == "" {
+			self.JsonResponseWithError(http, errors.New("请选择容器并填写需要执行的命令"), 500)
+			return
+		}
+	case logic.CronTypeImagePull:
+		if params.ImageName == "" {
+			self.JsonResponseWithError(http, errors.New("请填写镜像名称"), 500)
+			return
+		}
+	case logic.CronTypePrune:
+		for _, item := range params.PruneTarget {
+			if !function.InArray([]string{
+				logic.CronPruneContainer, logic.CronPruneImage, logic.CronPruneImageUnused,
+				logic.CronPruneVolume, logic.CronPruneNetwork, logic.CronPruneBuildCache,
+			}, item) {
+				self.JsonResponseWithError(http, errors.New("不支持清理的资源类型 "+item), 500)
+				return
+			}
+		}
+		if function.IsEmptyArray(params.PruneTarget) {
+			self.JsonResponseWithError(http, errors.New("请选择需要清理的资源"), 500)
+			return
+		}
+	case logic.CronTypeComposeDeploy:
+		if composeRow, _ := dao.Compose.Where(dao.Compose.ID.Eq(params.ComposeId)).First(); composeRow == nil {
+			self.JsonResponseWithError(http, errors.New("Compose 任务不存在"), 500)
+			return
+		}
+	}
+	if params.ConcurrencyPolicy == "" {
+		params.ConcurrencyPolicy = logic.CronPolicySkip
+	}
+
+	cronNew := &entity.Cron{
+		Title:      params.Title,
+		Expression: params.Expression,
+		Enable:     params.Enable,
+		Setting: &accessor.CronSettingOption{
+			Type:              params.Type,
+			ContainerName:     params.ContainerName,
+			Command:           params.Command,
+			WorkDir:           params.WorkDir,
+			ImageName:         params.ImageName,
+			PruneTarget:       params.PruneTarget,
+			ComposeId:         params.ComposeId,
+			Timeout:           params.Timeout,
+			ConcurrencyPolicy: params.ConcurrencyPolicy,
+			KeepLogs:          params.KeepLogs,
+		},
+	}
+	var err error
+	if params.Id <= 0 {
+		cronNew.CreatedAt = time.Now().Local()
+		err = dao.Cron.Create(cronNew)
+	} else {
+		if cronRow, _ := dao.Cron.Where(dao.Cron.ID.Eq(params.Id)).First(); cronRow == nil {
+			self.JsonResponseWithError(http, errors.New("任务不存在"), 500)
+			return
+		}
+		_, err = dao.Cron.Where(dao.Cron.ID.Eq(params.Id)).
+			Select(dao.Cron.Title, dao.Cron.Expression, dao.Cron.Enable, dao.Cron.Setting).
+			Updates(cronNew)
+		cronNew.ID = params.Id
+	}
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	if err = (logic.Cron{}).Reload(); err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"id": cronNew.ID,
+	})
+	return
+}
+
+func (self Cron) GetList(http *gin.Context) {
+	type ParamsValidate struct {
+		Title string `json:"title"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	query := dao.Cron.Order(dao.Cron.ID.Desc())
+	if params.Title != "" {
+		query = query.Where(dao.Cron.Title.Like("%" + params.Title + "%"))
+	}
+	list, _ := query.Find()
+
+	type item struct {
+		*entity.Cron
+		NextRunAt *time.Time      `json:"nextRunAt"`
+		Running   bool            `json:"running"`
+		LastLog   *entity.CronLog `json:"lastLog"`
+	}
+	result := make([]*item, 0, len(list))
+	for _, row := range list {
+		cronItem := &item{
+			Cron:    row,
+			Running: logic.Cron{}.IsRunning(row.ID),
+		}
+		if next := (logic.Cron{}).GetNextRunAt(row.ID); !next.IsZero() {
+			cronItem.NextRunAt = &next
+		}
+		cronItem.LastLog, _ = dao.CronLog.Where(dao.CronLog.CronID.Eq(row.ID)).Order(dao.CronLog.ID.Desc()).First()
+		result = append(result, cronItem)
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"list": result,
+	})
+	return
+}
+
+func (self Cron) Delete(http *gin.Context) {
+	type ParamsValidate struct {
+		Id []int32 `json:"id" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	err := logic.Cron{}.Delete(params.Id...)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonSuccessResponse(http)
+	return
+}
+
+func (self Cron) RunNow(http *gin.Context) {
+	type ParamsValidate struct {
+		Id int32 `json:"id" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	cronRow, _ := dao.Cron.Where(dao.Cron.ID.Eq(params.Id)).First()
+	if cronRow == nil {
+		self.JsonResponseWithError(http, errors.New("任务不存在"), 500)
+		return
+	}
+	logRow, err := logic.Cron{}.Run(cronRow, logic.CronTriggerManual)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"log": logRow,
+	})
+	return
+}
+
+func (self Cron) GetLogList(http *gin.Context) {
+	type ParamsValidate struct {
+		CronId   int32  `json:"cronId" binding:"required"`
+		Status   string `json:"status"`
+		Page     int    `json:"page,default=1" binding:"omitempty,gt=0"`
+		PageSize int    `json:"pageSize" binding:"omitempty,gt=1"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+	query := dao.CronLog.Where(dao.CronLog.CronID.Eq(params.CronId)).Order(dao.CronLog.ID.Desc())
+	if params.Status != "" {
+		query = query.Where(dao.CronLog.Status.Eq(params.Status))
+	}
+	list, total, _ := query.FindByPage((params.Page-1)*params.PageSize, params.PageSize)
+	self.JsonResponseWithoutError(http, gin.H{
+		"total": total,
+		"page":  params.Page,
+		"list":  list,
+	})
+	return
+}
+
+// GetNextRunTime 预览表达式接下来的执行时间
+func (self Cron) GetNextRunTime(http *gin.Context) {
+	type ParamsValidate struct {
+		Expression string `json:"expression" binding:"required"`
+		Count      int    `json:"count" binding:"omitempty,gte=1,lte=20"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Count < 1 {
+		params.Count = 5
+	}
+	schedule, err := cron.Parse(params.Expression)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	result := make([]time.Time, 0, params.Count)
+	next := time.Now()
+	for i := 0; i < params.Count; i++ {
+		next = schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		result = append(result, next)
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"list": result,
+	})
+	return
+}
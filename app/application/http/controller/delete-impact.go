@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"errors"
+	"github.com/donknap/dpanel/app/application/logic"
+	"github.com/gin-gonic/gin"
+)
+
+// 未确认影响范围时返回 409，客户端需要先获取影响报告并带上确认码重新提交
+func deleteConfirmErrorCode(err error) int {
+	if errors.Is(err, logic.ErrDeleteNotConfirmed) {
+		return 409
+	}
+	return 500
+}
+
+// GetDeleteImpact 获取删除容器的影响报告及确认码，筛选条件与批量操作相同
+func (self Container) GetDeleteImpact(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5     []string `json:"md5"`
+		Name    string   `json:"name"`
+		Label   string   `json:"label"`
+		Compose string   `json:"compose"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	list, err := logic.DockerTask{}.ContainerBatchSelect(&logic.ContainerBatchOption{
+		Md5:     params.Md5,
+		Name:    params.Name,
+		Label:   params.Label,
+		Compose: params.Compose,
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	md5List := make([]string, 0, len(list))
+	for _, item := range list {
+		md5List = append(md5List, item.ID)
+	}
+	result, err := logic.DeleteImpact{}.Report(logic.DeleteTargetContainer, md5List)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
+
+func (self Volume) GetDeleteImpact(http *gin.Context) {
+	type ParamsValidate struct {
+		Name []string `json:"name" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	result, err := logic.DeleteImpact{}.Report(logic.DeleteTargetVolume, params.Name)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
+
+func (self Network) GetDeleteImpact(http *gin.Context) {
+	type ParamsValidate struct {
+		Name []string `json:"name" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	result, err := logic.DeleteImpact{}.Report(logic.DeleteTargetNetwork, params.Name)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+	"github.com/gin-gonic/gin"
+)
+
+// ApplyCleanRecommend 清理用户在清理建议中确认过的项目，已经不符合条件的项目会被跳过
+func (self Home) ApplyCleanRecommend(http *gin.Context) {
+	type ParamsValidate struct {
+		Type         string   `json:"type" binding:"required,oneof=danglingImage stoppedContainer unusedVolume buildCache"`
+		StoppedDays  int      `json:"stoppedDays"`
+		Id           []string `json:"id" binding:"required"`
+		ConfirmToken string   `json:"confirmToken"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.StoppedDays <= 0 {
+		params.StoppedDays = 7
+	}
+	recommend, err := commonLogic.DiskUsage{}.Match(params.Type, params.StoppedDays, params.Id)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	// 删除容器及存储卷同样需要先确认影响范围
+	target := ""
+	switch params.Type {
+	case commonLogic.DiskCleanStoppedContainer:
+		target = logic.DeleteTargetContainer
+	case commonLogic.DiskCleanUnusedVolume:
+		target = logic.DeleteTargetVolume
+	}
+	if target != "" && len(recommend.Items) > 0 {
+		idList := make([]string, 0, len(recommend.Items))
+		for _, item := range recommend.Items {
+			idList = append(idList, item.Id)
+		}
+		if err = (logic.DeleteImpact{}).Confirm(target, idList, params.ConfirmToken); err != nil {
+			self.JsonResponseWithError(http, err, deleteConfirmErrorCode(err))
+			return
+		}
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"result": logic.DockerTask{}.DiskClean(recommend),
+	})
+	return
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"github.com/donknap/dpanel/app/application/logic"
+	"github.com/gin-gonic/gin"
+	"os"
+)
+
+func (self Explorer) GetDiff(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5  string `json:"md5" binding:"required"`
+		Path string `json:"path"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	result, err := logic.ContainerDiff{}.GetTree(params.Md5, params.Path)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
+
+func (self Explorer) ExportDiff(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5  string `json:"md5" binding:"required"`
+		Path string `json:"path"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	tempFile, err := os.CreateTemp("", "dpanel-diff")
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	defer func() {
+		_ = tempFile.Close()
+		_ = os.Remove(tempFile.Name())
+	}()
+	err = logic.ContainerDiff{}.Export(params.Md5, params.Path, tempFile)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	http.Header("Content-Type", "application/gzip")
+	http.Header("Content-Disposition", "attachment; filename=diff.tar.gz")
+	http.File(tempFile.Name())
+	return
+}
@@ -22,22 +22,8 @@ func (self Image) TagRemote(http *gin.Context) {
 	if !self.Validate(http, &params) {
 		return
 	}
-	var authString string
 	tagDetail := logic.Image{}.GetImageTagDetail(params.Tag)
-
-	proxyList := make([]string, 0)
-	// 从官方仓库拉取镜像不用权限
-	registry, _ := dao.Registry.Where(dao.Registry.ServerAddress.Eq(tagDetail.Registry)).Find()
-	for _, registryRow := range registry {
-		if registryRow.Setting.Username == tagDetail.Namespace {
-			authString = logic.Image{}.GetRegistryAuthString(registryRow.ServerAddress, registryRow.Setting.Username, registryRow.Setting.Password)
-		}
-		proxyList = append(proxyList, registryRow.Setting.Proxy...)
-	}
-
-	if authString == "" && registry != nil && len(registry) > 0 {
-		authString = logic.Image{}.GetRegistryAuthString(registry[0].ServerAddress, registry[0].Setting.Username, registry[0].Setting.Password)
-	}
+	authString, proxyList := logic.Image{}.GetRegistryAuth(params.Tag)
 
 	var proxyUrl string
 
@@ -59,45 +45,16 @@ func (self Image) TagRemote(http *gin.Context) {
 			return
 		}
 	} else {
-		// 如果仓库采用了代理地址，则优先使用，代理地址无法拉取后，尝试用原始的地址拉取
-		if !function.IsEmptyArray(proxyList) {
-			proxyList = append(proxyList, tagDetail.Registry)
-			var err error
-			for _, value := range proxyList {
-				proxyImageTag := strings.Trim(strings.TrimPrefix(value, "https://"), "/")
-				if tagDetail.Registry == "docker.io" && strings.Count(tagDetail.ImageName, "/") == 0 {
-					proxyImageTag += "/library"
-				}
-				proxyImageTag += "/" + tagDetail.ImageName
-
-				err = logic.DockerTask{}.ImageRemote(&logic.ImageRemoteOption{
-					Auth:     authString,
-					Type:     params.Type,
-					Tag:      proxyImageTag,
-					Platform: params.Platform,
-				})
-				if err == nil {
-					proxyUrl = value
-					// 如果使用了加速，需要给镜像 tag 一个原来的名称
-					_ = docker.Sdk.Client.ImageTag(docker.Sdk.Ctx, proxyImageTag, params.Tag)
-					break
-				}
-			}
-			if err != nil {
-				self.JsonResponseWithError(http, err, 500)
-				return
-			}
-		} else {
-			err := logic.DockerTask{}.ImageRemote(&logic.ImageRemoteOption{
-				Auth:     authString,
-				Type:     params.Type,
-				Tag:      params.Tag,
-				Platform: params.Platform,
-			})
-			if err != nil {
-				self.JsonResponseWithError(http, err, 500)
-				return
-			}
+		var err error
+		proxyUrl, err = logic.DockerTask{}.ImageRemoteWithProxy(&logic.ImageRemoteOption{
+			Auth:     authString,
+			Type:     params.Type,
+			Tag:      params.Tag,
+			Platform: params.Platform,
+		}, proxyList)
+		if err != nil {
+			self.JsonResponseWithError(http, err, 500)
+			return
 		}
 	}
 	self.JsonResponseWithoutError(http, gin.H{
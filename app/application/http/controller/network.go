@@ -3,6 +3,7 @@ package controller
 import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
+	"github.com/donknap/dpanel/app/application/logic"
 	"github.com/donknap/dpanel/common/function"
 	"github.com/donknap/dpanel/common/service/docker"
 	"github.com/gin-gonic/gin"
@@ -76,14 +77,20 @@ func (self Network) Prune(http *gin.Context) {
 
 func (self Network) Delete(http *gin.Context) {
 	type ParamsValidate struct {
-		Name []string `json:"name" binding:"required"`
+		Name         []string `json:"name" binding:"required"`
+		ConfirmToken string   `json:"confirmToken"`
 	}
 	params := ParamsValidate{}
 	if !self.Validate(http, &params) {
 		return
 	}
+	err := logic.DeleteImpact{}.Confirm(logic.DeleteTargetNetwork, params.Name, params.ConfirmToken)
+	if err != nil {
+		self.JsonResponseWithError(http, err, deleteConfirmErrorCode(err))
+		return
+	}
 	for _, name := range params.Name {
-		err := docker.Sdk.Client.NetworkRemove(docker.Sdk.Ctx, name)
+		err = docker.Sdk.Client.NetworkRemove(docker.Sdk.Ctx, name)
 		if err != nil {
 			self.JsonResponseWithError(http, err, 500)
 			return
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"errors"
+	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/gin-gonic/gin"
+	"path/filepath"
+)
+
+func (self RunLog) GetArchiveList(http *gin.Context) {
+	type ParamsValidate struct {
+		ContainerName string `json:"containerName"`
+		Page          int    `json:"page,default=1" binding:"omitempty,gt=0"`
+		PageSize      int    `json:"pageSize" binding:"omitempty,gt=1"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+	// 没有新的归档产生时，在查看列表时清理过期的归档
+	logic.LogArchive{}.Purge(logic.LogArchive{}.GetSetting(), "")
+
+	query := dao.LogArchive.Order(dao.LogArchive.ID.Desc())
+	if params.ContainerName != "" {
+		query = query.Where(dao.LogArchive.ContainerName.Eq(params.ContainerName))
+	}
+	list, total, _ := query.FindByPage((params.Page-1)*params.PageSize, params.PageSize)
+	self.JsonResponseWithoutError(http, gin.H{
+		"total": total,
+		"page":  params.Page,
+		"list":  list,
+	})
+	return
+}
+
+func (self RunLog) Archive(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5 string `json:"md5" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	row, err := logic.LogArchive{}.Archive(params.Md5, "", logic.LogArchiveReasonManual)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	if row == nil {
+		self.JsonResponseWithError(http, errors.New("容器没有可以归档的日志"), 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"archive": row,
+	})
+	return
+}
+
+func (self RunLog) DownloadArchive(http *gin.Context) {
+	type ParamsValidate struct {
+		Id int32 `json:"id" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	row, _ := dao.LogArchive.Where(dao.LogArchive.ID.Eq(params.Id)).First()
+	if row == nil {
+		self.JsonResponseWithError(http, errors.New("归档不存在"), 500)
+		return
+	}
+	path, err := logic.LogArchive{}.GetRealPath(row)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	http.Header("Content-Type", "application/gzip")
+	http.Header("Content-Disposition", "attachment; filename="+filepath.Base(row.FileName))
+	http.File(path)
+	return
+}
+
+func (self RunLog) DeleteArchive(http *gin.Context) {
+	type ParamsValidate struct {
+		Id []int32 `json:"id" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	list, _ := dao.LogArchive.Where(dao.LogArchive.ID.In(params.Id...)).Find()
+	for _, item := range list {
+		if err := (logic.LogArchive{}).Delete(item); err != nil {
+			self.JsonResponseWithError(http, err, 500)
+			return
+		}
+	}
+	self.JsonSuccessResponse(http)
+	return
+}
+
+func (self RunLog) GetArchiveSetting(http *gin.Context) {
+	self.JsonResponseWithoutError(http, gin.H{
+		"setting": logic.LogArchive{}.GetSetting(),
+	})
+	return
+}
+
+func (self RunLog) SaveArchiveSetting(http *gin.Context) {
+	type ParamsValidate struct {
+		Disable       bool `json:"disable"`
+		RetentionDays int  `json:"retentionDays" binding:"omitempty,gte=1,lte=3650"`
+		MaxCount      int  `json:"maxCount" binding:"omitempty,gte=1,lte=1000"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	err := commonLogic.Setting{}.Save(&entity.Setting{
+		GroupName: commonLogic.SettingGroupSetting,
+		Name:      commonLogic.SettingGroupSettingLogArchive,
+		Value: &accessor.SettingValueOption{
+			LogArchive: &accessor.LogArchiveSetting{
+				Disable:       params.Disable,
+				RetentionDays: params.RetentionDays,
+				MaxCount:      params.MaxCount,
+			},
+		},
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonSuccessResponse(http)
+	return
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func (self RunLog) Search(http *gin.Context) {
+	type ParamsValidate struct {
+		Query         string   `json:"query"`
+		Since         int64    `json:"since"`
+		Until         int64    `json:"until"`
+		ContainerName []string `json:"containerName"`
+		Compose       string   `json:"compose"`
+		Page          int      `json:"page,default=1" binding:"omitempty,gt=0"`
+		PageSize      int      `json:"pageSize" binding:"omitempty,lte=500"`
+		Context       int      `json:"context" binding:"omitempty,gte=0,lte=10"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 50
+	}
+	option := &logic.LogIndexSearchOption{
+		Query:         params.Query,
+		ContainerName: params.ContainerName,
+		Compose:       params.Compose,
+		Page:          params.Page,
+		PageSize:      params.PageSize,
+		Context:       params.Context,
+	}
+	if params.Since > 0 {
+		option.Since = time.Unix(params.Since, 0)
+	}
+	if params.Until > 0 {
+		option.Until = time.Unix(params.Until, 0)
+	}
+	list, total, err := logic.LogIndex{}.Search(option)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"total": total,
+		"page":  params.Page,
+		"list":  list,
+	})
+	return
+}
+
+func (self RunLog) GetIndexSetting(http *gin.Context) {
+	self.JsonResponseWithoutError(http, gin.H{
+		"setting": logic.LogIndex{}.GetSetting(),
+	})
+	return
+}
+
+func (self RunLog) SaveIndexSetting(http *gin.Context) {
+	type ParamsValidate struct {
+		Enable        bool   `json:"enable"`
+		Label         string `json:"label"`
+		RetentionDays int    `json:"retentionDays" binding:"omitempty,gte=1,lte=365"`
+		MaxRows       int    `json:"maxRows" binding:"omitempty,gte=1000"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	err := commonLogic.Setting{}.Save(&entity.Setting{
+		GroupName: commonLogic.SettingGroupSetting,
+		Name:      commonLogic.SettingGroupSettingLogIndex,
+		Value: &accessor.SettingValueOption{
+			LogIndex: &accessor.LogIndexSetting{
+				Enable:        params.Enable,
+				Label:         params.Label,
+				RetentionDays: params.RetentionDays,
+				MaxRows:       params.MaxRows,
+			},
+		},
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	go logic.LogIndex{}.Refresh()
+	self.JsonSuccessResponse(http)
+	return
+}
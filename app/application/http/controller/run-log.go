@@ -1,13 +1,16 @@
 package controller
 
 import (
-	"github.com/docker/docker/api/types/container"
-	"github.com/donknap/dpanel/common/function"
-	"github.com/donknap/dpanel/common/service/docker"
+	"errors"
+	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/we7coreteam/w7-rangine-go/v2/src/http/controller"
-	"io"
+	"log/slog"
+	"regexp"
 	"strconv"
+	"strings"
 )
 
 type RunLog struct {
@@ -25,29 +28,136 @@ func (self RunLog) Run(http *gin.Context) {
 	if !self.Validate(http, &params) {
 		return
 	}
-	out, err := docker.Sdk.Client.ContainerLogs(docker.Sdk.Ctx, params.Md5, container.LogsOptions{
-		ShowStderr: true,
-		ShowStdout: true,
-		Tail:       strconv.Itoa(params.LineTotal),
-		Follow:     false,
+	output := strings.Builder{}
+	err := logic.ContainerLog{}.Stream(http.Request.Context(), &logic.ContainerLogOption{
+		Md5:  params.Md5,
+		Tail: strconv.Itoa(params.LineTotal),
+	}, func(line *logic.ContainerLogLine) error {
+		output.WriteString(line.Content + "\n")
+		return nil
 	})
 	if err != nil {
 		self.JsonResponseWithError(http, err, 500)
 		return
 	}
-	output, _ := io.ReadAll(out)
-	cleanOut := function.BytesCleanFunc([]rune(string(output)), func(b rune) bool {
-		return b == '\ufffd'
-	})
+	cleanOut := output.String()
 	if params.Download {
 		http.Header("Content-Type", "text/plain")
 		http.Header("Content-Disposition", "attachment; filename="+params.Md5+".log")
-		http.Data(200, "text/plain", []byte(string(cleanOut)))
+		http.Data(200, "text/plain", []byte(cleanOut))
 		return
 	} else {
 		self.JsonResponseWithoutError(http, gin.H{
-			"log": string(cleanOut),
+			"log": cleanOut,
 		})
 	}
 	return
 }
+
+func (self RunLog) Download(http *gin.Context) {
+	type ParamsValidate struct {
+		Md5        string `json:"md5" binding:"required"`
+		Since      string `json:"since"`
+		Until      string `json:"until"`
+		Tail       string `json:"tail"`
+		Timestamps bool   `json:"timestamps"`
+		Stdout     bool   `json:"stdout"`
+		Stderr     bool   `json:"stderr"`
+		Grep       string `json:"grep"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if _, err := regexp.Compile(params.Grep); err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	http.Header("Content-Type", "text/plain; charset=utf-8")
+	http.Header("Content-Disposition", "attachment; filename="+params.Md5+".log")
+	http.Status(200)
+	// 逐行写入响应，不在内存中缓存全部日志
+	err := logic.ContainerLog{}.Stream(http.Request.Context(), &logic.ContainerLogOption{
+		Md5:        params.Md5,
+		Since:      params.Since,
+		Until:      params.Until,
+		Tail:       params.Tail,
+		Timestamps: params.Timestamps,
+		Stdout:     params.Stdout,
+		Stderr:     params.Stderr,
+		Grep:       params.Grep,
+	}, func(line *logic.ContainerLogLine) error {
+		content := line.Content
+		if line.Time != "" {
+			content = line.Time + " " + content
+		}
+		_, err := http.Writer.WriteString(content + "\n")
+		return err
+	})
+	if err != nil {
+		slog.Debug("run log download", "md5", params.Md5, "error", err.Error())
+	}
+	return
+}
+
+func (self RunLog) WsStream(http *gin.Context) {
+	if !websocket.IsWebSocketUpgrade(http.Request) {
+		self.JsonResponseWithError(http, errors.New("please connect using websocket"), 500)
+		return
+	}
+	type ParamsValidate struct {
+		Md5        string `uri:"md5" binding:"required"`
+		Follow     bool   `form:"follow"`
+		Since      string `form:"since"`
+		Until      string `form:"until"`
+		Tail       string `form:"tail,default=100"`
+		Timestamps bool   `form:"timestamps"`
+		Stdout     bool   `form:"stdout"`
+		Stderr     bool   `form:"stderr"`
+		Grep       string `form:"grep"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if (commonLogic.User{}).GetUsernameByContext(http) == "" {
+		self.JsonResponseWithError(http, errors.New("请先登录"), 401)
+		return
+	}
+	if _, err := regexp.Compile(params.Grep); err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	client, err := commonLogic.NewClientConn(http, &commonLogic.ClientOptions{})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	go client.ReadMessage()
+	go func() {
+		err := logic.ContainerLog{}.Stream(client.CtxContext, &logic.ContainerLogOption{
+			Md5:        params.Md5,
+			Follow:     params.Follow,
+			Since:      params.Since,
+			Until:      params.Until,
+			Tail:       params.Tail,
+			Timestamps: params.Timestamps,
+			Stdout:     params.Stdout,
+			Stderr:     params.Stderr,
+			Grep:       params.Grep,
+		}, func(line *logic.ContainerLogLine) error {
+			client.WriteMessage("containerLog", line)
+			return client.CtxContext.Err()
+		})
+		// 连接已经断开时不需要再发送结束消息
+		if client.CtxContext.Err() != nil {
+			return
+		}
+		result := gin.H{}
+		if err != nil {
+			result["error"] = err.Error()
+		}
+		client.WriteMessage("containerLogEnd", result)
+		_ = client.Close()
+	}()
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"errors"
+	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/gin-gonic/gin"
+)
+
+func (self Site) GetRevisionList(http *gin.Context) {
+	type ParamsValidate struct {
+		SiteName string `json:"siteName" binding:"required"`
+		Page     int    `json:"page,default=1" binding:"omitempty,gt=0"`
+		PageSize int    `json:"pageSize" binding:"omitempty"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+	list, total, _ := dao.SiteRevision.Where(dao.SiteRevision.SiteName.Eq(params.SiteName)).
+		Order(dao.SiteRevision.ID.Desc()).
+		FindByPage((params.Page-1)*params.PageSize, params.PageSize)
+	self.JsonResponseWithoutError(http, gin.H{
+		"total": total,
+		"page":  params.Page,
+		"list":  list,
+	})
+	return
+}
+
+func (self Site) DiffRevision(http *gin.Context) {
+	type ParamsValidate struct {
+		Id        int32 `json:"id" binding:"required"`
+		CompareId int32 `json:"compareId"` // 为空时与上一个版本对比
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	revisionRow, _ := dao.SiteRevision.Where(dao.SiteRevision.ID.Eq(params.Id)).First()
+	if revisionRow == nil {
+		self.JsonResponseWithError(http, errors.New("配置版本不存在"), 500)
+		return
+	}
+	var err error
+	compareRow := revisionRow
+	if params.CompareId > 0 {
+		compareRow, _ = dao.SiteRevision.Where(dao.SiteRevision.ID.Eq(params.CompareId)).First()
+	} else {
+		compareRow, err = logic.SiteRevision{}.GetPrevious(revisionRow)
+	}
+	if err != nil || compareRow == nil {
+		self.JsonResponseWithError(http, errors.New("对比的配置版本不存在"), 500)
+		return
+	}
+	// 始终以较早的版本作为旧版本
+	oldRow, newRow := compareRow, revisionRow
+	if oldRow.ID > newRow.ID {
+		oldRow, newRow = newRow, oldRow
+	}
+	diff, err := logic.SiteRevision{}.Diff(oldRow.Env, newRow.Env)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"old":  oldRow,
+		"new":  newRow,
+		"diff": diff,
+	})
+	return
+}
+
+func (self Site) RollbackRevision(http *gin.Context) {
+	type ParamsValidate struct {
+		Id int32 `json:"id" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	result, err := logic.SiteRevision{}.Rollback(params.Id, commonLogic.User{}.GetUsernameByContext(http))
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"result": result,
+	})
+	return
+}
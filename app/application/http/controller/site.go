@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/docker/docker/api/types/container"
 	"github.com/donknap/dpanel/app/application/logic"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
 	"github.com/donknap/dpanel/common/accessor"
 	"github.com/donknap/dpanel/common/dao"
 	"github.com/donknap/dpanel/common/entity"
@@ -128,6 +129,8 @@ func (self Site) CreateByImage(http *gin.Context) {
 				self.JsonResponseWithError(http, err, 500)
 				return
 			}
+			// 重建后旧容器的日志会丢失，先归档一份
+			logic.LogArchive{}.ArchiveQuietly(params.SiteName, "", logic.LogArchiveReasonRecreate)
 			err = docker.Sdk.Client.ContainerRemove(docker.Sdk.Ctx, params.SiteName, container.RemoveOptions{})
 			if err != nil {
 				self.JsonResponseWithError(http, err, 500)
@@ -206,6 +209,7 @@ func (self Site) CreateByImage(http *gin.Context) {
 		Status:  accessor.StatusSuccess,
 		Message: "",
 	})
+	_ = logic.SiteRevision{}.Add(siteRow.SiteName, &buildParams, commonLogic.User{}.GetUsernameByContext(http), "部署")
 
 	self.JsonResponseWithoutError(http, gin.H{"siteId": siteRow.ID})
 	return
@@ -371,3 +375,20 @@ func (self Site) UpdateTitle(http *gin.Context) {
 	self.JsonSuccessResponse(http)
 	return
 }
+
+func (self Site) ParseDockerRun(http *gin.Context) {
+	type ParamsValidate struct {
+		Command string `json:"command" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	result, err := logic.DockerRun{}.Parse(params.Command)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, result)
+	return
+}
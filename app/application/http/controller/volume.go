@@ -4,6 +4,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/volume"
+	"github.com/donknap/dpanel/app/application/logic"
 	"github.com/donknap/dpanel/common/service/docker"
 	"github.com/gin-gonic/gin"
 	"github.com/we7coreteam/w7-rangine-go/v2/src/http/controller"
@@ -215,14 +216,20 @@ func (self Volume) Prune(http *gin.Context) {
 
 func (self Volume) Delete(http *gin.Context) {
 	type ParamsValidate struct {
-		Name []string `json:"name" binding:"required"`
+		Name         []string `json:"name" binding:"required"`
+		ConfirmToken string   `json:"confirmToken"`
 	}
 	params := ParamsValidate{}
 	if !self.Validate(http, &params) {
 		return
 	}
+	err := logic.DeleteImpact{}.Confirm(logic.DeleteTargetVolume, params.Name, params.ConfirmToken)
+	if err != nil {
+		self.JsonResponseWithError(http, err, deleteConfirmErrorCode(err))
+		return
+	}
 	for _, name := range params.Name {
-		err := docker.Sdk.Client.VolumeRemove(docker.Sdk.Ctx, name, false)
+		err = docker.Sdk.Client.VolumeRemove(docker.Sdk.Ctx, name, false)
 		if err != nil {
 			self.JsonResponseWithError(http, err, 500)
 			return
@@ -0,0 +1,214 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/service/notice"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AlertMetricCpu    = "cpu"
+	AlertMetricMemory = "memory"
+
+	AlertSelectorName    = "name"
+	AlertSelectorLabel   = "label"
+	AlertSelectorCompose = "compose"
+
+	AlertStatusPending  = "pending"
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+var (
+	alertState     = make(map[string]*AlertItem)
+	alertStateLock = sync.RWMutex{}
+)
+
+type AlertItem struct {
+	RuleId        int32     `json:"ruleId"`
+	RuleTitle     string    `json:"ruleTitle"`
+	ContainerId   string    `json:"containerId"`
+	ContainerName string    `json:"containerName"`
+	Metric        string    `json:"metric"`
+	Comparator    string    `json:"comparator"`
+	Threshold     float64   `json:"threshold"`
+	Value         float64   `json:"value"`
+	Status        string    `json:"status"`
+	Silenced      bool      `json:"silenced"`
+	Notified      bool      `json:"notified"` // 是否已经发送过触发通知，静默期间触发的告警在静默结束后补发
+	StartAt       time.Time `json:"startAt"`  // 首次满足条件的时间
+	FiringAt      time.Time `json:"firingAt"` // 持续时间达到后触发的时间
+}
+
+type Alert struct {
+}
+
+// Evaluate 根据采样数据计算规则的触发及恢复
+func (self Alert) Evaluate(sample []*ContainerStatSample, now time.Time) {
+	ruleList, err := dao.AlertRule.Where(dao.AlertRule.Enable.Is(true)).Find()
+	if err != nil {
+		slog.Debug("alert", "rule", err.Error())
+		return
+	}
+
+	alertStateLock.Lock()
+	defer alertStateLock.Unlock()
+
+	matched := make(map[string]struct{})
+	for _, rule := range ruleList {
+		if rule.Setting == nil {
+			continue
+		}
+		for _, item := range sample {
+			if !self.MatchSelector(rule.Setting.Selector, item) {
+				continue
+			}
+			value := self.metricValue(rule.Setting.Metric, item)
+			if !self.compare(rule.Setting.Comparator, value, rule.Setting.Threshold) {
+				continue
+			}
+			key := self.stateKey(rule.ID, item.Id)
+			matched[key] = struct{}{}
+
+			state, ok := alertState[key]
+			if !ok {
+				state = &AlertItem{
+					RuleId:        rule.ID,
+					RuleTitle:     rule.Title,
+					ContainerId:   item.Id,
+					ContainerName: item.Name,
+					Metric:        rule.Setting.Metric,
+					Comparator:    rule.Setting.Comparator,
+					Threshold:     rule.Setting.Threshold,
+					Status:        AlertStatusPending,
+					StartAt:       now,
+				}
+				alertState[key] = state
+			}
+			state.Value = value
+			state.Silenced = rule.Setting.IsSilenced(now)
+			if state.Status == AlertStatusPending && now.Sub(state.StartAt) >= time.Duration(rule.Setting.Duration)*time.Second {
+				state.Status = AlertStatusFiring
+				state.FiringAt = now
+			}
+			if state.Status == AlertStatusFiring && !state.Notified && !state.Silenced {
+				state.Notified = true
+				self.notify(rule, *state)
+			}
+		}
+	}
+
+	// 不再满足条件（包含规则被删除、禁用及容器停止）的告警恢复
+	for key, state := range alertState {
+		if _, ok := matched[key]; ok {
+			continue
+		}
+		delete(alertState, key)
+		// 没有收到过触发通知的告警也不发送恢复通知
+		if state.Status != AlertStatusFiring || !state.Notified {
+			continue
+		}
+		state.Status = AlertStatusResolved
+		for _, rule := range ruleList {
+			if rule.ID == state.RuleId && rule.Setting != nil && !rule.Setting.IsSilenced(now) {
+				self.notify(rule, *state)
+			}
+		}
+	}
+}
+
+// GetFiringList 获取当前正在触发的告警
+func (self Alert) GetFiringList() []*AlertItem {
+	alertStateLock.RLock()
+	defer alertStateLock.RUnlock()
+
+	result := make([]*AlertItem, 0)
+	for _, item := range alertState {
+		if item.Status == AlertStatusFiring {
+			temp := *item
+			result = append(result, &temp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FiringAt.Before(result[j].FiringAt)
+	})
+	return result
+}
+
+func (self Alert) MatchSelector(selector accessor.AlertSelectorItem, item *ContainerStatSample) bool {
+	switch selector.Type {
+	case AlertSelectorName:
+		if selector.Value == "" {
+			return true
+		}
+		ok, _ := path.Match(selector.Value, item.Name)
+		return ok
+	case AlertSelectorLabel:
+		key, value, hasValue := strings.Cut(selector.Value, "=")
+		labelValue, ok := item.Labels[key]
+		if !ok {
+			return false
+		}
+		return !hasValue || labelValue == value
+	case AlertSelectorCompose:
+		return item.ComposeProject != "" && item.ComposeProject == selector.Value
+	}
+	return false
+}
+
+func (self Alert) metricValue(metric string, item *ContainerStatSample) float64 {
+	switch metric {
+	case AlertMetricCpu:
+		return item.CpuPercent()
+	case AlertMetricMemory:
+		return item.Memory.Percent
+	}
+	return 0
+}
+
+func (self Alert) compare(comparator string, value, threshold float64) bool {
+	switch comparator {
+	case "gt":
+		return value > threshold
+	case "gte":
+		return value >= threshold
+	case "lt":
+		return value < threshold
+	case "lte":
+		return value <= threshold
+	}
+	return false
+}
+
+func (self Alert) stateKey(ruleId int32, containerId string) string {
+	return fmt.Sprintf("%d:%s", ruleId, containerId)
+}
+
+func (self Alert) notify(rule *entity.AlertRule, item AlertItem) {
+	message := fmt.Sprintf("%s %s %s %.2f%%，当前 %.2f%%", item.ContainerName, item.Metric, item.Comparator, item.Threshold, item.Value)
+	if item.Status == AlertStatusFiring {
+		_ = notice.Message{}.Error("containerAlert", rule.Title, message)
+	} else {
+		_ = notice.Message{}.Success("containerAlert", rule.Title, message)
+	}
+	if len(rule.Setting.Webhook) == 0 {
+		return
+	}
+	// webhook 请求较慢时不影响下一轮检测
+	go func() {
+		for _, url := range rule.Setting.Webhook {
+			err := notice.Webhook{}.Send(url, item)
+			if err != nil {
+				slog.Debug("alert", "webhook", url, "error", err.Error())
+			}
+		}
+	}()
+}
@@ -0,0 +1,239 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/donknap/dpanel/common/service/docker"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	containerBatchDefaultWorker = 5
+	containerBatchMaxWorker     = 20
+	containerBatchMinPrefix     = 12
+)
+
+type ContainerBatchOption struct {
+	TaskId       string
+	Operate      string   // start stop restart pause unpause delete upgrade
+	Md5          []string // 容器 id 或是名称
+	Name         string   // 名称匹配，支持 * ? 通配符
+	Label        string   // key 或是 key=value
+	Compose      string   // compose 项目名称
+	Worker       int      // 同时执行的数量
+	DeleteVolume bool
+	ConfirmToken string // 删除时需要先通过影响报告获取确认码
+	Username     string
+}
+
+type ContainerBatchItemResult struct {
+	Md5     string `json:"md5"`
+	Name    string `json:"name"`
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+type ContainerBatchResult struct {
+	TaskId  string                      `json:"taskId"`
+	Total   int                         `json:"total"`
+	Success int                         `json:"success"`
+	Failed  int                         `json:"failed"`
+	List    []*ContainerBatchItemResult `json:"list"`
+}
+
+// ContainerBatch 并发对多个容器执行操作，单个容器失败不影响其它容器，进度通过 ws 推送
+func (self DockerTask) ContainerBatch(option *ContainerBatchOption) (*ContainerBatchResult, error) {
+	containerList, err := self.ContainerBatchSelect(option)
+	if err != nil {
+		return nil, err
+	}
+	if option.Operate == "delete" {
+		md5List := make([]string, 0, len(containerList))
+		for _, item := range containerList {
+			md5List = append(md5List, item.ID)
+		}
+		if err = (DeleteImpact{}).Confirm(DeleteTargetContainer, md5List, option.ConfirmToken); err != nil {
+			return nil, err
+		}
+	}
+	if option.TaskId == "" {
+		option.TaskId = fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	worker := option.Worker
+	if worker <= 0 {
+		worker = containerBatchDefaultWorker
+	}
+	if worker > containerBatchMaxWorker {
+		worker = containerBatchMaxWorker
+	}
+
+	result := &ContainerBatchResult{
+		TaskId: option.TaskId,
+		Total:  len(containerList),
+		List:   make([]*ContainerBatchItemResult, len(containerList)),
+	}
+	done := 0
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	jobs := make(chan int)
+	for i := 0; i < worker; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				item := containerList[index]
+				itemResult := &ContainerBatchItemResult{
+					Md5:  item.ID,
+					Name: strings.TrimPrefix(item.Names[0], "/"),
+				}
+				if err := self.containerBatchOperate(option, item); err != nil {
+					itemResult.Error = err.Error()
+				} else {
+					itemResult.Success = true
+				}
+
+				lock.Lock()
+				result.List[index] = itemResult
+				done++
+				progress := &docker.ProgressContainerBatch{
+					TaskId:  option.TaskId,
+					Total:   result.Total,
+					Done:    done,
+					Md5:     itemResult.Md5,
+					Name:    itemResult.Name,
+					Success: itemResult.Success,
+					Error:   itemResult.Error,
+				}
+				lock.Unlock()
+
+				select {
+				case docker.QueueContainerBatchMessage <- progress:
+				default:
+				}
+			}
+		}()
+	}
+	for i := range containerList {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, item := range result.List {
+		if item.Success {
+			result.Success++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// ContainerBatchSelect 按条件筛选容器，多个条件同时生效
+func (self DockerTask) ContainerBatchSelect(option *ContainerBatchOption) ([]types.Container, error) {
+	if len(option.Md5) == 0 && option.Name == "" && option.Label == "" && option.Compose == "" {
+		return nil, errors.New("请至少指定一个筛选条件")
+	}
+	filtersArgs := filters.NewArgs()
+	if option.Label != "" {
+		filtersArgs.Add("label", option.Label)
+	}
+	if option.Compose != "" {
+		filtersArgs.Add("label", "com.docker.compose.project="+option.Compose)
+	}
+	containerList, err := docker.Sdk.Client.ContainerList(docker.Sdk.Ctx, container.ListOptions{
+		All:     true,
+		Filters: filtersArgs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 指定容器时需要完全匹配 id 或名称，id 前缀至少需要 12 位并且只能匹配到一个容器
+	selected := make(map[string]struct{})
+	for _, md5 := range option.Md5 {
+		if md5 == "" {
+			continue
+		}
+		matched := make([]string, 0)
+		for _, item := range containerList {
+			if len(item.Names) == 0 {
+				continue
+			}
+			if item.ID == md5 || strings.TrimPrefix(item.Names[0], "/") == md5 {
+				matched = []string{item.ID}
+				break
+			}
+			if len(md5) >= containerBatchMinPrefix && strings.HasPrefix(item.ID, md5) {
+				matched = append(matched, item.ID)
+			}
+		}
+		if len(matched) > 1 {
+			return nil, errors.New("容器 id " + md5 + " 匹配到多个容器，请使用完整的 id 或名称")
+		}
+		for _, id := range matched {
+			selected[id] = struct{}{}
+		}
+	}
+	result := make([]types.Container, 0)
+	for _, item := range containerList {
+		if len(item.Names) == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(item.Names[0], "/")
+		if option.Name != "" {
+			if match, _ := path.Match(option.Name, name); !match {
+				continue
+			}
+		}
+		if len(option.Md5) > 0 {
+			if _, ok := selected[item.ID]; !ok {
+				continue
+			}
+		}
+		result = append(result, item)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("没有符合条件的容器")
+	}
+	return result, nil
+}
+
+func (self DockerTask) containerBatchOperate(option *ContainerBatchOption, item types.Container) error {
+	switch option.Operate {
+	case "start":
+		return docker.Sdk.Client.ContainerStart(docker.Sdk.Ctx, item.ID, container.StartOptions{})
+	case "stop":
+		return docker.Sdk.Client.ContainerStop(docker.Sdk.Ctx, item.ID, container.StopOptions{})
+	case "restart":
+		return docker.Sdk.Client.ContainerRestart(docker.Sdk.Ctx, item.ID, container.StopOptions{})
+	case "pause":
+		return docker.Sdk.Client.ContainerPause(docker.Sdk.Ctx, item.ID)
+	case "unpause":
+		return docker.Sdk.Client.ContainerUnpause(docker.Sdk.Ctx, item.ID)
+	case "delete":
+		_, err := self.ContainerDelete(&ContainerDeleteOption{
+			Md5:          item.ID,
+			DeleteVolume: option.DeleteVolume,
+		})
+		return err
+	case "upgrade":
+		result, err := self.ContainerUpgrade(&ContainerUpgradeOption{
+			Md5:      item.ID,
+			Username: option.Username,
+		})
+		if err != nil {
+			return err
+		}
+		if result.Rollback {
+			return errors.New("新容器启动失败，已回滚到旧容器")
+		}
+		return nil
+	}
+	return errors.New("不支持的操作 " + option.Operate)
+}
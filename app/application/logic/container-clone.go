@@ -0,0 +1,284 @@
+package logic
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
+	"github.com/donknap/dpanel/common/service/docker"
+	"github.com/donknap/dpanel/common/service/notice"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CloneNetworkShare   = "share"   // 加入与原容器相同的网络
+	CloneNetworkIsolate = "isolate" // 不加入原容器的自定义网络
+)
+
+const (
+	cloneVolumeFromPath = "/dpanel-clone-from"
+	cloneVolumeToPath   = "/dpanel-clone-to"
+)
+
+type ContainerCloneOption struct {
+	Md5         string
+	SiteName    string
+	SiteTitle   string
+	ReplacePort bool   // 宿主机端口替换为空闲端口
+	CopyVolume  bool   // 复制命名存储卷的数据到新的存储卷
+	Network     string // share isolate
+	Username    string
+}
+
+type ContainerCloneResult struct {
+	SiteId      int32             `json:"siteId"`
+	ContainerId string            `json:"containerId"`
+	Ports       map[string]string `json:"ports"`   // 替换后的端口，原端口 => 新端口
+	Volumes     map[string]string `json:"volumes"` // 复制后的存储卷，原存储卷 => 新存储卷
+}
+
+// ContainerClone 复制容器的全部配置，以新的名称创建容器
+func (self DockerTask) ContainerClone(option *ContainerCloneOption) (*ContainerCloneResult, error) {
+	info, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, option.Md5)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, option.SiteName); err == nil {
+		return nil, errors.New("容器 " + option.SiteName + " 已经存在，请更换名称")
+	}
+	envOption, err := Site{}.GetEnvOptionByContainer(option.Md5)
+	if err != nil {
+		return nil, err
+	}
+	oldName := strings.TrimPrefix(info.Name, "/")
+	envOption.Name = option.SiteName
+	envOption.Hostname = ""
+	envOption.Label = Site{}.StripInheritLabel(envOption.Label, info.Image)
+	result := &ContainerCloneResult{
+		Ports:   make(map[string]string),
+		Volumes: make(map[string]string),
+	}
+
+	// 固定 ip 及网络别名会与原容器冲突，不复制
+	network := make([]accessor.NetworkItem, 0)
+	if option.Network != CloneNetworkIsolate {
+		for _, item := range envOption.Network {
+			if item.Name == oldName {
+				continue
+			}
+			network = append(network, accessor.NetworkItem{
+				Name: item.Name,
+			})
+		}
+	}
+	envOption.Network = network
+
+	if option.ReplacePort {
+		usedPort := make([]string, 0)
+		for i, item := range envOption.Ports {
+			if item.Host == "" {
+				continue
+			}
+			_, protocol, _ := strings.Cut(item.Dest, "/")
+			port, err := self.getFreePort(protocol, usedPort)
+			if err != nil {
+				return nil, err
+			}
+			usedPort = append(usedPort, port)
+			result.Ports[item.Host] = port
+			envOption.Ports[i].Host = port
+		}
+	}
+
+	volumes := make([]accessor.VolumeItem, 0)
+	for _, item := range envOption.Volumes {
+		if anonymousVolumeRegexp.MatchString(item.Host) {
+			envOption.VolumesDefault = append(envOption.VolumesDefault, accessor.VolumeItem{
+				Dest: item.Dest,
+			})
+			continue
+		}
+		// 绑定宿主机目录时共用目录
+		if option.CopyVolume && !strings.Contains(item.Host, "/") {
+			newVolume := option.SiteName + "-" + item.Host
+			if strings.HasPrefix(item.Host, oldName) {
+				newVolume = option.SiteName + strings.TrimPrefix(item.Host, oldName)
+			}
+			_ = notice.Message{}.Info("containerCreate", "正在复制存储卷", item.Host)
+			err = self.copyVolume(info.Image, item.Host, newVolume)
+			if err != nil {
+				return nil, err
+			}
+			result.Volumes[item.Host] = newVolume
+			item.Host = newVolume
+		}
+		volumes = append(volumes, item)
+	}
+	envOption.Volumes = volumes
+
+	siteRow := &entity.Site{
+		SiteName:  option.SiteName,
+		SiteTitle: option.SiteTitle,
+		Env:       &envOption,
+		Status:    StatusStop,
+		ContainerInfo: &accessor.SiteContainerInfoOption{
+			ID: "",
+		},
+	}
+	_, _ = dao.Site.Unscoped().Where(dao.Site.SiteName.Eq(option.SiteName)).Delete()
+	err = dao.Site.Create(siteRow)
+	if err != nil {
+		return nil, err
+	}
+	containerId, err := self.ContainerCreate(&CreateContainerOption{
+		SiteName:    siteRow.SiteName,
+		SiteId:      siteRow.ID,
+		BuildParams: &envOption,
+	})
+	if err != nil {
+		if containerId != "" {
+			_ = docker.Sdk.Client.ContainerRemove(docker.Sdk.Ctx, containerId, container.RemoveOptions{
+				Force: true,
+			})
+		}
+		_, _ = dao.Site.Where(dao.Site.ID.Eq(siteRow.ID)).Updates(entity.Site{
+			Status:  accessor.StatusError,
+			Message: err.Error(),
+		})
+		_, _ = dao.Site.Where(dao.Site.ID.Eq(siteRow.ID)).Delete()
+		for _, name := range result.Volumes {
+			_ = docker.Sdk.Client.VolumeRemove(docker.Sdk.Ctx, name, true)
+		}
+		return nil, err
+	}
+	_, _ = dao.Site.Where(dao.Site.ID.Eq(siteRow.ID)).Updates(&entity.Site{
+		ContainerInfo: &accessor.SiteContainerInfoOption{
+			ID: containerId,
+		},
+		Status:  accessor.StatusSuccess,
+		Message: "",
+	})
+	_ = SiteRevision{}.Add(siteRow.SiteName, &envOption, option.Username, "克隆自 "+oldName)
+
+	result.SiteId = siteRow.ID
+	result.ContainerId = containerId
+	return result, nil
+}
+
+// 获取一个未被占用的宿主机端口，未启动的容器绑定的端口也需要排除
+func (self DockerTask) getFreePort(protocol string, exclude []string) (string, error) {
+	for i := 0; i < 10; i++ {
+		var port string
+		if protocol == "udp" {
+			conn, err := net.ListenPacket("udp", "0.0.0.0:0")
+			if err != nil {
+				return "", err
+			}
+			port = strconv.Itoa(conn.LocalAddr().(*net.UDPAddr).Port)
+			_ = conn.Close()
+		} else {
+			listener, err := net.Listen("tcp", "0.0.0.0:0")
+			if err != nil {
+				return "", err
+			}
+			port = strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+			_ = listener.Close()
+		}
+		if function.InArray(exclude, port) {
+			continue
+		}
+		if list, _ := docker.Sdk.ContainerByField("publish", port); len(list) > 0 {
+			continue
+		}
+		return port, nil
+	}
+	return "", errors.New("没有找到可用的端口")
+}
+
+// 借助一个不启动的临时容器同时挂载两个存储卷，通过 docker cp 的方式复制数据
+func (self DockerTask) copyVolume(image string, from string, to string) error {
+	if _, err := docker.Sdk.Client.VolumeInspect(docker.Sdk.Ctx, to); err == nil {
+		return errors.New("存储卷 " + to + " 已经存在，请更换名称")
+	}
+	_, err := docker.Sdk.Client.VolumeCreate(docker.Sdk.Ctx, volume.CreateOptions{
+		Name: to,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// 复制失败时删除新建的存储卷
+		if err != nil {
+			_ = docker.Sdk.Client.VolumeRemove(docker.Sdk.Ctx, to, true)
+		}
+	}()
+	helper, err := docker.Sdk.Client.ContainerCreate(docker.Sdk.Ctx, &container.Config{
+		Image: image,
+	}, &container.HostConfig{
+		Mounts: []mount.Mount{
+			{Type: mount.TypeVolume, Source: from, Target: cloneVolumeFromPath, ReadOnly: true},
+			{Type: mount.TypeVolume, Source: to, Target: cloneVolumeToPath},
+		},
+	}, nil, nil, fmt.Sprintf("dpanel-clone-volume-%d", time.Now().UnixNano()))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = docker.Sdk.Client.ContainerRemove(docker.Sdk.Ctx, helper.ID, container.RemoveOptions{
+			Force: true,
+		})
+	}()
+
+	out, _, err := docker.Sdk.Client.CopyFromContainer(docker.Sdk.Ctx, helper.ID, cloneVolumeFromPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	// 打包的文件都在 dpanel-clone-from 目录下，需要去掉这一层目录
+	prefix := strings.TrimPrefix(cloneVolumeFromPath, "/") + "/"
+	reader, writer := io.Pipe()
+	go func() {
+		tarReader := tar.NewReader(out)
+		tarWriter := tar.NewWriter(writer)
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				_ = writer.CloseWithError(err)
+				return
+			}
+			if !strings.HasPrefix(header.Name, prefix) {
+				continue
+			}
+			header.Name = strings.TrimPrefix(header.Name, prefix)
+			if header.Typeflag == tar.TypeLink {
+				header.Linkname = strings.TrimPrefix(header.Linkname, prefix)
+			}
+			if err = tarWriter.WriteHeader(header); err != nil {
+				_ = writer.CloseWithError(err)
+				return
+			}
+			if _, err = io.Copy(tarWriter, tarReader); err != nil {
+				_ = writer.CloseWithError(err)
+				return
+			}
+		}
+		_ = writer.CloseWithError(tarWriter.Close())
+	}()
+	err = docker.Sdk.Client.CopyToContainer(docker.Sdk.Ctx, helper.ID, cloneVolumeToPath, reader, container.CopyToContainerOptions{})
+	_ = reader.Close()
+	return err
+}
@@ -0,0 +1,89 @@
+package logic
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/service/docker"
+	"log/slog"
+)
+
+type ContainerDeleteOption struct {
+	Md5          string
+	DeleteImage  bool
+	DeleteVolume bool
+	DeleteLink   bool
+}
+
+// ContainerDelete 删除容器及面板中关联的网络、域名、站点记录，返回站点 id
+func (self DockerTask) ContainerDelete(option *ContainerDeleteOption) (siteId int32, err error) {
+	containerInfo, err := docker.Sdk.ContainerInfo(option.Md5)
+	if err != nil {
+		return 0, err
+	}
+	siteRow, _ := dao.Site.Where(dao.Site.SiteName.Eq(containerInfo.Name)).
+		Or(dao.Site.ContainerInfo.Eq(&accessor.SiteContainerInfoOption{
+			ID: containerInfo.ID,
+		})).First()
+
+	if siteRow != nil && siteRow.SiteName != "" {
+		// 删除网络
+		// 获取该容器的网络，退出里面的容器
+		networkInfo, err := docker.Sdk.Client.NetworkInspect(docker.Sdk.Ctx, siteRow.SiteName, network.InspectOptions{})
+		if err == nil {
+			for md5, _ := range networkInfo.Containers {
+				err = docker.Sdk.Client.NetworkDisconnect(docker.Sdk.Ctx, siteRow.SiteName, md5, true)
+				if err != nil {
+					return 0, err
+				}
+			}
+			err = docker.Sdk.Client.NetworkRemove(docker.Sdk.Ctx, siteRow.SiteName)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	err = docker.Sdk.Client.ContainerStop(docker.Sdk.Ctx, containerInfo.ID, container.StopOptions{})
+	if err != nil {
+		return 0, err
+	}
+	LogArchive{}.ArchiveQuietly(containerInfo.ID, "", LogArchiveReasonDelete)
+	err = docker.Sdk.Client.ContainerRemove(docker.Sdk.Ctx, containerInfo.ID, container.RemoveOptions{
+		RemoveVolumes: option.DeleteVolume,
+		RemoveLinks:   option.DeleteLink,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if option.DeleteImage {
+		_, err = docker.Sdk.Client.ImageRemove(docker.Sdk.Ctx, containerInfo.Image, image.RemoveOptions{
+			Force:         true,
+			PruneChildren: true,
+		})
+		if err != nil {
+			slog.Debug("remove container image", "error", err.Error())
+		}
+	}
+
+	if option.DeleteVolume {
+		for _, item := range containerInfo.Mounts {
+			if item.Type == mount.TypeVolume {
+				err = docker.Sdk.Client.VolumeRemove(docker.Sdk.Ctx, item.Name, false)
+				if err != nil {
+					slog.Debug("remove container volume", "error", err.Error())
+				}
+			}
+		}
+	}
+
+	// 删除域名、配置、证书及站点记录
+	DeleteImpact{}.CleanContainer(containerInfo.Name, containerInfo.ID)
+	if siteRow != nil {
+		return siteRow.ID, nil
+	}
+	return 0, nil
+}
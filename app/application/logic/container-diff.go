@@ -0,0 +1,197 @@
+package logic
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"github.com/docker/docker/api/types/container"
+	"github.com/donknap/dpanel/common/service/docker"
+	"io"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+)
+
+const ContainerDiffKindParent = -1 // 本身没有变化，只是变化文件的上级目录
+
+type ContainerDiffItem struct {
+	Name     string               `json:"name"`
+	Path     string               `json:"path"`
+	Kind     int                  `json:"kind"` // 0 修改 1 新增 2 删除
+	Size     int64                `json:"size"` // 目录为所有变化文件大小的合计，-1 表示无法获取
+	IsDir    bool                 `json:"isDir"`
+	Children []*ContainerDiffItem `json:"children,omitempty"`
+}
+
+type ContainerDiffResult struct {
+	List     []*ContainerDiffItem `json:"list"`
+	Added    int                  `json:"added"`
+	Modified int                  `json:"modified"`
+	Deleted  int                  `json:"deleted"`
+}
+
+type ContainerDiff struct {
+}
+
+// GetChangeList 获取容器中发生变化的文件，只返回 prefix 目录下的文件
+func (self ContainerDiff) GetChangeList(md5 string, prefix string) ([]container.FilesystemChange, error) {
+	changeList, err := docker.Sdk.Client.ContainerDiff(docker.Sdk.Ctx, md5)
+	if err != nil {
+		return nil, err
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return changeList, nil
+	}
+	result := make([]container.FilesystemChange, 0)
+	for _, item := range changeList {
+		if item.Path == prefix || strings.HasPrefix(item.Path, prefix+"/") {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// GetTree 将变化的文件转换为树形结构，容器运行时通过 explorer 插件获取文件大小
+func (self ContainerDiff) GetTree(md5 string, prefix string) (*ContainerDiffResult, error) {
+	changeList, err := self.GetChangeList(md5, prefix)
+	if err != nil {
+		return nil, err
+	}
+	result := &ContainerDiffResult{
+		List: make([]*ContainerDiffItem, 0),
+	}
+
+	statList := make(map[string]*fileStatResult)
+	if len(changeList) > 0 {
+		pathList := make([]string, 0)
+		for _, item := range changeList {
+			if item.Kind != container.ChangeDelete {
+				pathList = append(pathList, item.Path)
+			}
+		}
+		if explorer, err := NewExplorer(md5); err == nil {
+			statList, err = explorer.GetFileStat(pathList)
+			if err != nil {
+				slog.Debug("container diff", "get file stat", err.Error())
+			}
+		}
+	}
+
+	nodeList := make(map[string]*ContainerDiffItem)
+	var getNode func(filePath string) *ContainerDiffItem
+	getNode = func(filePath string) *ContainerDiffItem {
+		if node, ok := nodeList[filePath]; ok {
+			return node
+		}
+		node := &ContainerDiffItem{
+			Name:  path.Base(filePath),
+			Path:  filePath,
+			Kind:  ContainerDiffKindParent,
+			Size:  -1,
+			IsDir: true,
+		}
+		nodeList[filePath] = node
+		parentPath := path.Dir(filePath)
+		if parentPath == "/" || filePath == prefix {
+			result.List = append(result.List, node)
+		} else {
+			parent := getNode(parentPath)
+			parent.Children = append(parent.Children, node)
+		}
+		return node
+	}
+
+	prefix = strings.TrimSuffix(prefix, "/")
+	for _, item := range changeList {
+		node := getNode(item.Path)
+		node.Kind = int(item.Kind)
+		node.IsDir = false
+		if stat, ok := statList[item.Path]; ok {
+			node.Size = stat.Size
+			node.IsDir = stat.IsDir
+		}
+		switch item.Kind {
+		case container.ChangeAdd:
+			result.Added++
+		case container.ChangeModify:
+			result.Modified++
+		case container.ChangeDelete:
+			result.Deleted++
+		}
+	}
+	for _, item := range result.List {
+		self.sumSize(item)
+	}
+	self.sort(result.List)
+	return result, nil
+}
+
+// 目录的大小为下级变化文件的合计
+func (self ContainerDiff) sumSize(item *ContainerDiffItem) int64 {
+	if len(item.Children) == 0 {
+		return item.Size
+	}
+	item.IsDir = true
+	var total int64 = 0
+	for _, child := range item.Children {
+		if size := self.sumSize(child); size > 0 {
+			total += size
+		}
+	}
+	item.Size = total
+	return total
+}
+
+func (self ContainerDiff) sort(list []*ContainerDiffItem) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].IsDir != list[j].IsDir {
+			return list[i].IsDir
+		}
+		return list[i].Name < list[j].Name
+	})
+	for _, item := range list {
+		self.sort(item.Children)
+	}
+}
+
+// Export 将新增及修改的文件打包为 tar.gz，目录只保留变化的文件
+func (self ContainerDiff) Export(md5 string, prefix string, writer io.Writer) error {
+	changeList, err := self.GetChangeList(md5, prefix)
+	if err != nil {
+		return err
+	}
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, item := range changeList {
+		if item.Kind == container.ChangeDelete {
+			continue
+		}
+		out, _, err := docker.Sdk.Client.CopyFromContainer(docker.Sdk.Ctx, md5, item.Path)
+		if err != nil {
+			// 文件可能在获取变化后被删除
+			slog.Debug("container diff", "export", item.Path, "error", err.Error())
+			continue
+		}
+		tarReader := tar.NewReader(out)
+		header, err := tarReader.Next()
+		// 目录中变化的文件会单独导出，这里只需要保留目录本身
+		if err == nil {
+			header.Name = strings.TrimPrefix(item.Path, "/")
+			if header.Typeflag == tar.TypeDir {
+				header.Name += "/"
+			}
+			if err = tarWriter.WriteHeader(header); err == nil && header.Typeflag != tar.TypeDir {
+				_, err = io.Copy(tarWriter, tarReader)
+			}
+		}
+		_ = out.Close()
+		if err != nil && err != io.EOF {
+			return err
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
@@ -0,0 +1,168 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
+	"github.com/donknap/dpanel/common/service/docker"
+	"github.com/donknap/dpanel/common/service/plugin"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ContainerExecStatusSuccess = "success"
+	ContainerExecStatusFailed  = "failed" // 退出码不为 0
+	ContainerExecStatusTimeout = "timeout"
+	ContainerExecStatusError   = "error" // 没有成功执行
+
+	containerExecDefaultTimeout = 60
+	containerExecMaxHistory     = 1000
+)
+
+type ContainerExecOption struct {
+	TaskId   string
+	Md5      []string // 容器 id 或是名称，与下面的条件同时生效
+	Name     string
+	Label    string
+	Compose  string
+	Cmd      string
+	Shell    bool // 通过 /bin/sh -c 执行，可以使用管道等 shell 语法
+	Env      []string
+	WorkDir  string
+	User     string
+	Timeout  int // 秒
+	Worker   int
+	Username string
+}
+
+type ContainerExecResult struct {
+	TaskId  string                  `json:"taskId"`
+	Total   int                     `json:"total"`
+	Success int                     `json:"success"`
+	Failed  int                     `json:"failed"`
+	List    []*entity.ContainerExec `json:"list"`
+}
+
+// ContainerExec 在多个容器中并发执行同一条命令，结果保存到执行记录中
+func (self DockerTask) ContainerExec(option *ContainerExecOption) (*ContainerExecResult, error) {
+	containerList, err := self.ContainerBatchSelect(&ContainerBatchOption{
+		Md5:     option.Md5,
+		Name:    option.Name,
+		Label:   option.Label,
+		Compose: option.Compose,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if option.TaskId == "" {
+		option.TaskId = fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	if option.Timeout <= 0 {
+		option.Timeout = containerExecDefaultTimeout
+	}
+	worker := option.Worker
+	if worker <= 0 {
+		worker = containerBatchDefaultWorker
+	}
+	if worker > containerBatchMaxWorker {
+		worker = containerBatchMaxWorker
+	}
+	cmd := function.CommandSplit(option.Cmd)
+	if option.Shell {
+		cmd = []string{"/bin/sh", "-c", option.Cmd}
+	}
+
+	result := &ContainerExecResult{
+		TaskId: option.TaskId,
+		Total:  len(containerList),
+		List:   make([]*entity.ContainerExec, len(containerList)),
+	}
+	wg := sync.WaitGroup{}
+	jobs := make(chan int)
+	for i := 0; i < worker; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				result.List[index] = self.containerExecOne(option, cmd, containerList[index])
+			}
+		}()
+	}
+	for i := range containerList {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, item := range result.List {
+		if item.Status == ContainerExecStatusSuccess {
+			result.Success++
+		} else {
+			result.Failed++
+		}
+	}
+	if err = dao.ContainerExec.CreateInBatches(result.List, 100); err != nil {
+		return result, err
+	}
+	self.containerExecPurge()
+	return result, nil
+}
+
+func (self DockerTask) containerExecOne(option *ContainerExecOption, cmd []string, item types.Container) *entity.ContainerExec {
+	row := &entity.ContainerExec{
+		TaskID:        option.TaskId,
+		ContainerName: strings.TrimPrefix(item.Names[0], "/"),
+		Cmd:           option.Cmd,
+		WorkDir:       option.WorkDir,
+		User:          option.User,
+		ExitCode:      -1,
+		Username:      option.Username,
+		CreatedAt:     time.Now(),
+	}
+	if item.State != "running" {
+		row.Status = ContainerExecStatusError
+		row.Error = "容器未运行"
+		return row
+	}
+	execResult, err := plugin.Command{}.Exec(docker.Sdk.Ctx, &plugin.ExecOption{
+		ContainerName: item.ID,
+		Cmd:           cmd,
+		Env:           option.Env,
+		WorkDir:       option.WorkDir,
+		User:          option.User,
+		Timeout:       time.Duration(option.Timeout) * time.Second,
+	})
+	if execResult != nil {
+		row.Stdout = execResult.Stdout
+		row.Stderr = execResult.Stderr
+		row.ExitCode = int32(execResult.ExitCode)
+		row.Duration = execResult.Duration
+	}
+	switch {
+	case err != nil:
+		row.Status = ContainerExecStatusError
+		row.Error = err.Error()
+	case execResult.TimedOut:
+		row.Status = ContainerExecStatusTimeout
+		row.Error = fmt.Sprintf("执行超时（%d 秒）", option.Timeout)
+	case execResult.ExitCode != 0:
+		row.Status = ContainerExecStatusFailed
+	default:
+		row.Status = ContainerExecStatusSuccess
+	}
+	if execResult != nil && execResult.Truncated {
+		row.Error = strings.TrimSpace(row.Error + " 输出内容过多，已截断")
+	}
+	return row
+}
+
+func (self DockerTask) containerExecPurge() {
+	last, _ := dao.ContainerExec.Order(dao.ContainerExec.ID.Desc()).Offset(containerExecMaxHistory).Take()
+	if last != nil {
+		_, _ = dao.ContainerExec.Where(dao.ContainerExec.ID.Lte(last.ID)).Delete()
+	}
+}
@@ -0,0 +1,416 @@
+package logic
+
+import (
+	"errors"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/service/docker"
+	"sort"
+	"strings"
+)
+
+const (
+	GraphNodeContainer = "container"
+	GraphNodeNetwork   = "network"
+	GraphNodeVolume    = "volume"
+	GraphNodeCompose   = "compose"
+
+	// 依赖关系，source 依赖 target，target 需要先启动
+	GraphEdgeLink        = "link"
+	GraphEdgeVolumesFrom = "volumesFrom"
+	GraphEdgeNetworkMode = "networkMode"
+	GraphEdgeDependsOn   = "dependsOn"
+	GraphEdgeReplace     = "replace"
+
+	// 归属关系，表示共用的资源
+	GraphEdgeNetwork = "network"
+	GraphEdgeVolume  = "volume"
+	GraphEdgeCompose = "compose"
+
+	GraphStatusMissing = "missing" // 被引用的容器已经不存在
+
+	composeServiceLabel   = "com.docker.compose.service"
+	composeDependsOnLabel = "com.docker.compose.depends_on"
+)
+
+type ContainerGraphNode struct {
+	Id     string `json:"id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Status string `json:"status,omitempty"`
+}
+
+type ContainerGraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+	Label  string `json:"label,omitempty"`
+}
+
+type ContainerGraphResult struct {
+	Nodes        []*ContainerGraphNode `json:"nodes"`
+	Edges        []*ContainerGraphEdge `json:"edges"`
+	StartOrder   []string              `json:"startOrder"`             // 按依赖关系排序后的容器名称
+	StopOrder    []string              `json:"stopOrder"`              // 启动顺序的倒序
+	Cycle        []string              `json:"cycle,omitempty"`        // 存在循环依赖的容器，无法排序
+	Dependents   []string              `json:"dependents,omitempty"`   // 直接或间接依赖指定容器的容器，删除后会受影响
+	Dependencies []string              `json:"dependencies,omitempty"` // 指定容器直接或间接依赖的容器
+}
+
+type ContainerGraphOption struct {
+	Md5   string // 为空时返回整个主机的关系图
+	Depth int    // 以指定容器为中心向外查找的层数
+}
+
+type ContainerGraph struct {
+	nodes map[string]*ContainerGraphNode
+	edges []*ContainerGraphEdge
+	// 容器 id 及名称到容器名称的映射
+	containerIndex map[string]string
+	// 容器 id 到容器名称的映射，用于按 id 前缀查找
+	containerIdIndex map[string]string
+}
+
+func NewContainerGraph() *ContainerGraph {
+	return &ContainerGraph{
+		nodes:            make(map[string]*ContainerGraphNode),
+		edges:            make([]*ContainerGraphEdge, 0),
+		containerIndex:   make(map[string]string),
+		containerIdIndex: make(map[string]string),
+	}
+}
+
+// Build 读取所有容器及面板中的站点配置，生成关系图
+func (self *ContainerGraph) Build(option *ContainerGraphOption) (*ContainerGraphResult, error) {
+	containerList, err := docker.Sdk.Client.ContainerList(docker.Sdk.Ctx, container.ListOptions{
+		All: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	infoList := make([]types.ContainerJSON, 0, len(containerList))
+	for _, item := range containerList {
+		info, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, item.ID)
+		if err != nil {
+			continue
+		}
+		name := strings.TrimPrefix(info.Name, "/")
+		self.nodes[self.containerNodeId(name)] = &ContainerGraphNode{
+			Id:     self.containerNodeId(name),
+			Type:   GraphNodeContainer,
+			Name:   name,
+			Status: info.State.Status,
+		}
+		self.containerIndex[info.ID] = name
+		self.containerIndex[name] = name
+		self.containerIdIndex[info.ID] = name
+		infoList = append(infoList, info)
+	}
+	// compose 项目中服务名称对应的容器
+	composeService := make(map[string]map[string][]string)
+	for _, info := range infoList {
+		project, service := info.Config.Labels[ComposeProjectLabel], info.Config.Labels[composeServiceLabel]
+		if project == "" || service == "" {
+			continue
+		}
+		if _, ok := composeService[project]; !ok {
+			composeService[project] = make(map[string][]string)
+		}
+		composeService[project][service] = append(composeService[project][service], strings.TrimPrefix(info.Name, "/"))
+	}
+
+	for _, info := range infoList {
+		name := strings.TrimPrefix(info.Name, "/")
+		source := self.containerNodeId(name)
+
+		// link 格式为 /target:/source/alias
+		for _, link := range info.HostConfig.Links {
+			target, alias, _ := strings.Cut(link, ":")
+			self.addDependency(source, strings.TrimPrefix(target, "/"), GraphEdgeLink, alias[strings.LastIndex(alias, "/")+1:])
+		}
+		// volumes from 格式为 name[:ro|rw]
+		for _, item := range info.HostConfig.VolumesFrom {
+			target, mode, _ := strings.Cut(item, ":")
+			self.addDependency(source, target, GraphEdgeVolumesFrom, mode)
+		}
+		if info.HostConfig.NetworkMode.IsContainer() {
+			self.addDependency(source, info.HostConfig.NetworkMode.ConnectedContainer(), GraphEdgeNetworkMode, "")
+		}
+		// depends_on 格式为 service:condition:restart，多个使用逗号分隔
+		if project := info.Config.Labels[ComposeProjectLabel]; project != "" {
+			composeId := self.addNode(GraphNodeCompose, project, "")
+			self.addEdge(source, composeId, GraphEdgeCompose, info.Config.Labels[composeServiceLabel])
+			for _, item := range strings.Split(info.Config.Labels[composeDependsOnLabel], ",") {
+				service, condition, _ := strings.Cut(item, ":")
+				if service == "" {
+					continue
+				}
+				condition, _, _ = strings.Cut(condition, ":")
+				targetList, ok := composeService[project][service]
+				if !ok {
+					self.addDependency(source, service, GraphEdgeDependsOn, condition)
+					continue
+				}
+				for _, target := range targetList {
+					self.addDependency(source, target, GraphEdgeDependsOn, condition)
+				}
+			}
+		}
+		if info.NetworkSettings != nil {
+			for networkName, item := range info.NetworkSettings.Networks {
+				// 默认网络不提供容器名称解析，不表示容器之间存在关系
+				if networkName == "bridge" || networkName == "host" || networkName == "none" {
+					continue
+				}
+				label := ""
+				if item != nil {
+					label = strings.Join(item.Aliases, ",")
+				}
+				self.addEdge(source, self.addNode(GraphNodeNetwork, networkName, ""), GraphEdgeNetwork, label)
+			}
+		}
+		for _, item := range info.Mounts {
+			if item.Type != mount.TypeVolume || item.Name == "" {
+				continue
+			}
+			self.addEdge(source, self.addNode(GraphNodeVolume, item.Name, ""), GraphEdgeVolume, item.Destination)
+		}
+	}
+
+	// 面板中创建的容器，替换 compose 中的服务时指向了其它容器
+	siteList, _ := dao.Site.Find()
+	for _, site := range siteList {
+		if site.Env == nil || site.SiteName == "" {
+			continue
+		}
+		if _, ok := self.containerIndex[site.SiteName]; !ok {
+			continue
+		}
+		for _, item := range site.Env.Replace {
+			if item.Target != "" {
+				self.addDependency(self.containerNodeId(site.SiteName), item.Target, GraphEdgeReplace, item.Depend)
+			}
+		}
+	}
+
+	result := &ContainerGraphResult{}
+	focus := ""
+	if option.Md5 != "" {
+		name, err := self.findContainer(option.Md5)
+		if err != nil {
+			return nil, err
+		}
+		focus = self.containerNodeId(name)
+		result.Dependents = self.reachable(focus, true)
+		result.Dependencies = self.reachable(focus, false)
+		self.neighbourhood(focus, option.Depth)
+	}
+	result.StartOrder, result.Cycle = self.sortContainer()
+	result.StopOrder = make([]string, len(result.StartOrder))
+	for i, name := range result.StartOrder {
+		result.StopOrder[len(result.StartOrder)-1-i] = name
+	}
+
+	result.Nodes = make([]*ContainerGraphNode, 0, len(self.nodes))
+	for _, node := range self.nodes {
+		result.Nodes = append(result.Nodes, node)
+	}
+	sort.Slice(result.Nodes, func(i, j int) bool {
+		return result.Nodes[i].Id < result.Nodes[j].Id
+	})
+	result.Edges = self.edges
+	return result, nil
+}
+
+func (self *ContainerGraph) IsDependencyEdge(edgeType string) bool {
+	switch edgeType {
+	case GraphEdgeLink, GraphEdgeVolumesFrom, GraphEdgeNetworkMode, GraphEdgeDependsOn, GraphEdgeReplace:
+		return true
+	}
+	return false
+}
+
+func (self *ContainerGraph) containerNodeId(name string) string {
+	return GraphNodeContainer + ":" + name
+}
+
+func (self *ContainerGraph) addNode(nodeType string, name string, status string) string {
+	id := nodeType + ":" + name
+	if _, ok := self.nodes[id]; !ok {
+		self.nodes[id] = &ContainerGraphNode{
+			Id:     id,
+			Type:   nodeType,
+			Name:   name,
+			Status: status,
+		}
+	}
+	return id
+}
+
+func (self *ContainerGraph) addEdge(source, target, edgeType, label string) {
+	for _, item := range self.edges {
+		if item.Source == source && item.Target == target && item.Type == edgeType {
+			return
+		}
+	}
+	self.edges = append(self.edges, &ContainerGraphEdge{
+		Source: source,
+		Target: target,
+		Type:   edgeType,
+		Label:  label,
+	})
+}
+
+// 依赖的容器可以是 id 或是名称，找不到时标记为已丢失
+func (self *ContainerGraph) addDependency(source string, target string, edgeType string, label string) {
+	name, err := self.findContainer(target)
+	status := ""
+	if err != nil {
+		name, status = target, GraphStatusMissing
+	}
+	self.addEdge(source, self.addNode(GraphNodeContainer, name, status), edgeType, label)
+}
+
+// findContainer 通过完整的 id、名称或是唯一的 id 前缀查找容器名称
+// 与批量操作相同，id 前缀至少需要 containerBatchMinPrefix 位
+func (self *ContainerGraph) findContainer(md5 string) (string, error) {
+	if name, ok := self.containerIndex[md5]; ok {
+		return name, nil
+	}
+	matched := make([]string, 0)
+	if len(md5) >= containerBatchMinPrefix {
+		for id, name := range self.containerIdIndex {
+			if strings.HasPrefix(id, md5) {
+				matched = append(matched, name)
+			}
+		}
+	}
+	if len(matched) > 1 {
+		return "", errors.New("容器 id " + md5 + " 匹配到多个容器，请使用完整的 id 或名称")
+	}
+	if len(matched) == 0 {
+		return "", errors.New("容器不存在")
+	}
+	return matched[0], nil
+}
+
+// reachable 沿依赖关系查找，reverse 为 true 时查找依赖 start 的容器
+func (self *ContainerGraph) reachable(start string, reverse bool) []string {
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+	result := make([]string, 0)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, edge := range self.edges {
+			if !self.IsDependencyEdge(edge.Type) {
+				continue
+			}
+			from, to := edge.Source, edge.Target
+			if reverse {
+				from, to = to, from
+			}
+			if from != current || visited[to] {
+				continue
+			}
+			visited[to] = true
+			queue = append(queue, to)
+			result = append(result, self.nodes[to].Name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// neighbourhood 只保留与 start 距离在 depth 以内的节点及边
+func (self *ContainerGraph) neighbourhood(start string, depth int) {
+	if depth <= 0 {
+		depth = 1
+	}
+	distance := map[string]int{start: 0}
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if distance[current] >= depth {
+			continue
+		}
+		for _, edge := range self.edges {
+			next := ""
+			if edge.Source == current {
+				next = edge.Target
+			} else if edge.Target == current {
+				next = edge.Source
+			}
+			if _, ok := distance[next]; next == "" || ok {
+				continue
+			}
+			distance[next] = distance[current] + 1
+			queue = append(queue, next)
+		}
+	}
+	for id := range self.nodes {
+		if _, ok := distance[id]; !ok {
+			delete(self.nodes, id)
+		}
+	}
+	edges := make([]*ContainerGraphEdge, 0)
+	for _, edge := range self.edges {
+		_, sourceOk := distance[edge.Source]
+		_, targetOk := distance[edge.Target]
+		if sourceOk && targetOk {
+			edges = append(edges, edge)
+		}
+	}
+	self.edges = edges
+}
+
+// sortContainer 按依赖关系对当前图中的容器拓扑排序，被依赖的容器排在前面
+func (self *ContainerGraph) sortContainer() (order []string, cycle []string) {
+	inDegree := make(map[string]int)
+	dependents := make(map[string][]string)
+	for id, node := range self.nodes {
+		if node.Type == GraphNodeContainer && node.Status != GraphStatusMissing {
+			inDegree[id] = 0
+		}
+	}
+	for _, edge := range self.edges {
+		if !self.IsDependencyEdge(edge.Type) {
+			continue
+		}
+		_, sourceOk := inDegree[edge.Source]
+		_, targetOk := inDegree[edge.Target]
+		if !sourceOk || !targetOk {
+			continue
+		}
+		inDegree[edge.Source]++
+		dependents[edge.Target] = append(dependents[edge.Target], edge.Source)
+	}
+	ready := make([]string, 0)
+	for id, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, id)
+		}
+	}
+	order = make([]string, 0, len(inDegree))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		current := ready[0]
+		ready = ready[1:]
+		order = append(order, self.nodes[current].Name)
+		delete(inDegree, current)
+		for _, item := range dependents[current] {
+			inDegree[item]--
+			if inDegree[item] == 0 {
+				ready = append(ready, item)
+			}
+		}
+	}
+	for id := range inDegree {
+		cycle = append(cycle, self.nodes[id].Name)
+	}
+	sort.Strings(cycle)
+	return order, cycle
+}
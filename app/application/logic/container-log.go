@@ -0,0 +1,148 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/donknap/dpanel/common/service/docker"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	ContainerLogStdout = "stdout"
+	ContainerLogStderr = "stderr"
+)
+
+// 单行日志超过此长度时直接输出，避免没有换行的日志一直占用内存
+const containerLogMaxLineSize = 64 * 1024
+
+type ContainerLogOption struct {
+	Md5        string
+	Follow     bool
+	Since      string // 时间戳或是 10m 这样的相对时间
+	Until      string
+	Tail       string // 行数，all 为全部
+	Timestamps bool
+	Stdout     bool
+	Stderr     bool
+	Grep       string // 正则表达式，只返回匹配的行
+}
+
+type ContainerLogLine struct {
+	Stream  string `json:"stream"`
+	Time    string `json:"time,omitempty"`
+	Content string `json:"content"`
+}
+
+type ContainerLog struct {
+}
+
+// Stream 逐行读取容器日志，非 tty 容器会区分 stdout 与 stderr
+// handler 返回错误时停止读取，follow 模式下需要通过 ctx 结束
+func (self ContainerLog) Stream(ctx context.Context, option *ContainerLogOption, handler func(line *ContainerLogLine) error) error {
+	var grep *regexp.Regexp
+	var err error
+	if option.Grep != "" {
+		grep, err = regexp.Compile(option.Grep)
+		if err != nil {
+			return err
+		}
+	}
+	if !option.Stdout && !option.Stderr {
+		option.Stdout, option.Stderr = true, true
+	}
+	if option.Tail == "" {
+		option.Tail = "all"
+	}
+	info, err := docker.Sdk.Client.ContainerInspect(ctx, option.Md5)
+	if err != nil {
+		return err
+	}
+	out, err := docker.Sdk.Client.ContainerLogs(ctx, option.Md5, container.LogsOptions{
+		ShowStdout: option.Stdout,
+		ShowStderr: option.Stderr,
+		Since:      option.Since,
+		Until:      option.Until,
+		Timestamps: option.Timestamps,
+		Follow:     option.Follow,
+		Tail:       option.Tail,
+	})
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	emit := func(stream string, line []byte) error {
+		item := &ContainerLogLine{
+			Stream:  stream,
+			Content: strings.ToValidUTF8(strings.TrimRight(string(line), "\r\n"), "�"),
+		}
+		if option.Timestamps {
+			if timestamp, content, ok := strings.Cut(item.Content, " "); ok {
+				item.Time, item.Content = timestamp, content
+			}
+		}
+		if grep != nil && !grep.MatchString(item.Content) {
+			return nil
+		}
+		return handler(item)
+	}
+	stdout := &containerLogWriter{stream: ContainerLogStdout, emit: emit}
+	stderr := &containerLogWriter{stream: ContainerLogStderr, emit: emit}
+
+	// tty 模式下只有一个输出流，没有 stdcopy 头信息
+	if info.Config != nil && info.Config.Tty {
+		_, err = io.Copy(stdout, out)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, out)
+	}
+	if err == nil {
+		err = stdout.Flush()
+	}
+	if err == nil {
+		err = stderr.Flush()
+	}
+	if err != nil && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// 将输出按行切分
+type containerLogWriter struct {
+	stream string
+	buffer []byte
+	emit   func(stream string, line []byte) error
+}
+
+func (self *containerLogWriter) Write(p []byte) (int, error) {
+	self.buffer = append(self.buffer, p...)
+	for {
+		index := bytes.IndexByte(self.buffer, '\n')
+		if index < 0 {
+			break
+		}
+		if err := self.emit(self.stream, self.buffer[:index+1]); err != nil {
+			return 0, err
+		}
+		self.buffer = self.buffer[index+1:]
+	}
+	if len(self.buffer) > containerLogMaxLineSize {
+		if err := self.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (self *containerLogWriter) Flush() error {
+	if len(self.buffer) == 0 {
+		return nil
+	}
+	line := self.buffer
+	self.buffer = nil
+	return self.emit(self.stream, line)
+}
@@ -0,0 +1,128 @@
+package logic
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/service/docker"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ComposeProjectLabel  = "com.docker.compose.project"
+	statSampleWorker     = 5
+	statHistoryRetention = time.Hour * 24 * 7
+)
+
+var statHistoryPurgeAt time.Time
+
+type ContainerStatSample struct {
+	*docker.ContainerStatsResult
+	Labels         map[string]string `json:"labels"`
+	ComposeProject string            `json:"composeProject"`
+	CpuLimit       float64           `json:"cpuLimit"` // 容器限制的核心数，未限制时为可用核心数
+}
+
+// CpuPercent 换算成占用限制核心数的百分比，范围 0-100
+func (self ContainerStatSample) CpuPercent() float64 {
+	if self.CpuLimit <= 0 {
+		return self.Cpu
+	}
+	return self.Cpu / self.CpuLimit
+}
+
+type ContainerStat struct {
+}
+
+// Sample 采集所有运行中容器的状态
+func (self ContainerStat) Sample() ([]*ContainerStatSample, error) {
+	list, err := docker.Sdk.Client.ContainerList(docker.Sdk.Ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("status", "running")),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ContainerStatSample, 0)
+	resultLock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	// docker 单次获取状态需要等待两次采样，这里并发获取
+	queue := make(chan struct{}, statSampleWorker)
+	for _, item := range list {
+		wg.Add(1)
+		queue <- struct{}{}
+		go func(item types.Container) {
+			defer func() {
+				<-queue
+				wg.Done()
+			}()
+			stats, err := docker.Sdk.ContainerStatsOnce(docker.Sdk.Ctx, item.ID)
+			if err != nil {
+				slog.Debug("container stat sample", "id", item.ID, "error", err.Error())
+				return
+			}
+			sample := &ContainerStatSample{
+				ContainerStatsResult: stats,
+				Labels:               item.Labels,
+				ComposeProject:       item.Labels[ComposeProjectLabel],
+				CpuLimit:             float64(stats.OnlineCpu),
+			}
+			if sample.Name == "" && len(item.Names) > 0 {
+				sample.Name = strings.TrimPrefix(item.Names[0], "/")
+			}
+			info, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, item.ID)
+			if err == nil && info.HostConfig.NanoCPUs > 0 {
+				sample.CpuLimit = float64(info.HostConfig.NanoCPUs) / 1e9
+			}
+			resultLock.Lock()
+			result = append(result, sample)
+			resultLock.Unlock()
+		}(item)
+	}
+	wg.Wait()
+	return result, nil
+}
+
+// MonitorLoop 定时采集容器状态，交给告警等模块处理
+// 切换 docker 环境后 Sdk 会被替换，这里不跟随 Sdk.Ctx 退出
+func (self ContainerStat) MonitorLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		sample, err := self.Sample()
+		if err != nil {
+			slog.Debug("container stat", "sample", err.Error())
+			continue
+		}
+		Alert{}.Evaluate(sample, now)
+		self.Save(sample, now)
+	}
+}
+
+// Save 保存采样数据用于资源建议等统计，按容器名称记录，重建容器后数据可以延续
+func (self ContainerStat) Save(sample []*ContainerStatSample, now time.Time) {
+	if len(sample) == 0 {
+		return
+	}
+	rows := make([]*entity.ContainerStat, 0, len(sample))
+	for _, item := range sample {
+		rows = append(rows, &entity.ContainerStat{
+			ContainerName: item.Name,
+			Cpu:           item.Cpu,
+			Memory:        int64(item.Memory.Usage),
+			CreatedAt:     now.Local(),
+		})
+	}
+	err := dao.ContainerStat.CreateInBatches(rows, 100)
+	if err != nil {
+		slog.Debug("container stat", "save", err.Error())
+	}
+	if now.Sub(statHistoryPurgeAt) >= time.Hour {
+		statHistoryPurgeAt = now
+		_, _ = dao.ContainerStat.Where(dao.ContainerStat.CreatedAt.Lt(now.Add(-statHistoryRetention))).Delete()
+	}
+}
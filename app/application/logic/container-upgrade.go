@@ -0,0 +1,311 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
+	"github.com/donknap/dpanel/common/service/docker"
+	"github.com/donknap/dpanel/common/service/notice"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	recreateBackupSuffix = "-dpanel-bak-"
+	recreateStableTime   = time.Second * 5  // 新容器需要保持运行的时间
+	recreateVerifyTime   = time.Second * 60 // 等待健康检查通过的最长时间
+)
+
+// 镜像 id，回滚到旧版本镜像时容器直接使用镜像 id 创建
+var imageIdRegexp = regexp.MustCompile(`^(sha256:)?[a-f0-9]{64}$`)
+
+type ContainerRecreateResult struct {
+	ContainerId string `json:"containerId"`
+	BackupName  string `json:"backupName"` // 保留的旧容器名称，为空表示已删除
+	Rollback    bool   `json:"rollback"`   // 新容器启动失败，已回滚到旧容器
+}
+
+type ContainerUpgradeOption struct {
+	Md5      string
+	Force    bool // 镜像没有变化时也重建容器
+	KeepOld  bool // 升级成功后保留旧容器
+	Platform string
+	Username string // 用于记录配置版本
+}
+
+type ContainerUpgradeResult struct {
+	ContainerRecreateResult
+	ImageName  string `json:"imageName"`
+	OldImageId string `json:"oldImageId"`
+	NewImageId string `json:"newImageId"`
+	ProxyUrl   string `json:"proxyUrl"`
+	Upgraded   bool   `json:"upgraded"`
+}
+
+// ContainerUpgrade 拉取容器镜像的最新版本，并使用当前配置重建容器
+func (self DockerTask) ContainerUpgrade(option *ContainerUpgradeOption) (*ContainerUpgradeResult, error) {
+	info, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, option.Md5)
+	if err != nil {
+		return nil, err
+	}
+	imageName := info.Config.Image
+	if imageName == "" || self.isImageId(imageName) {
+		return nil, errors.New("容器使用镜像 ID 创建，无法获取镜像的最新版本")
+	}
+	result := &ContainerUpgradeResult{
+		ImageName:  imageName,
+		OldImageId: info.Image,
+	}
+
+	_ = notice.Message{}.Info("containerUpgrade", "正在拉取镜像", imageName)
+	authString, proxyList := Image{}.GetRegistryAuth(imageName)
+	result.ProxyUrl, err = self.ImageRemoteWithProxy(&ImageRemoteOption{
+		Auth:     authString,
+		Type:     "pull",
+		Tag:      imageName,
+		Platform: option.Platform,
+	}, proxyList)
+	if err != nil {
+		return nil, err
+	}
+	newImageInfo, _, err := docker.Sdk.Client.ImageInspectWithRaw(docker.Sdk.Ctx, imageName)
+	if err != nil {
+		return nil, err
+	}
+	result.NewImageId = newImageInfo.ID
+	if newImageInfo.ID == info.Image && !option.Force {
+		return result, nil
+	}
+
+	envOption, err := Site{}.GetEnvOptionByContainer(info.ID)
+	if err != nil {
+		return nil, err
+	}
+	self.removeImageDefault(&envOption, info.Image)
+	envOption.ImageName = imageName
+	envOption.ImageId = newImageInfo.ID
+
+	recreateResult, err := self.ContainerRecreate(info, &envOption, option.KeepOld)
+	if recreateResult != nil {
+		result.ContainerRecreateResult = *recreateResult
+	}
+	if err != nil {
+		return result, err
+	}
+	result.Upgraded = true
+	_ = SiteRevision{}.Add(strings.TrimPrefix(info.Name, "/"), &envOption, option.Username, "升级镜像")
+	return result, nil
+}
+
+// ContainerRecreate 使用新的配置重建容器
+// 旧容器会先停止并重命名保留，新容器启动失败时删除新容器并恢复旧容器
+func (self DockerTask) ContainerRecreate(oldInfo types.ContainerJSON, envOption *accessor.SiteEnvOption, keepOld bool) (*ContainerRecreateResult, error) {
+	siteName := strings.TrimPrefix(oldInfo.Name, "/")
+	result := &ContainerRecreateResult{
+		BackupName: siteName + recreateBackupSuffix + time.Now().Format("20060102150405"),
+	}
+	if oldInfo.HostConfig.AutoRemove {
+		return nil, errors.New("开启了自动删除的容器停止后会被删除，无法重建")
+	}
+	oldRunning := oldInfo.State != nil && oldInfo.State.Running
+
+	if oldRunning {
+		err := docker.Sdk.Client.ContainerStop(docker.Sdk.Ctx, oldInfo.ID, container.StopOptions{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 旧容器不能随 docker 重启而自动启动，否则会与新容器冲突
+	_, err := docker.Sdk.Client.ContainerUpdate(docker.Sdk.Ctx, oldInfo.ID, container.UpdateConfig{
+		RestartPolicy: container.RestartPolicy{
+			Name: container.RestartPolicyDisabled,
+		},
+	})
+	if err != nil {
+		slog.Debug("container recreate", "update restart policy", err.Error())
+	}
+	err = docker.Sdk.Client.ContainerRename(docker.Sdk.Ctx, oldInfo.ID, result.BackupName)
+	if err != nil {
+		self.restoreOldContainer(oldInfo, siteName, false, oldRunning)
+		return nil, err
+	}
+
+	containerId, err := self.ContainerCreate(&CreateContainerOption{
+		SiteName:    siteName,
+		BuildParams: envOption,
+	})
+	if err == nil {
+		err = self.verifyContainerStart(containerId)
+	}
+	if err != nil {
+		_ = notice.Message{}.Error("containerRecreate", siteName, "新容器启动失败，正在回滚：", err.Error())
+		if containerId != "" {
+			_ = docker.Sdk.Client.ContainerRemove(docker.Sdk.Ctx, containerId, container.RemoveOptions{
+				Force: true,
+			})
+		}
+		self.restoreOldContainer(oldInfo, siteName, true, oldRunning)
+		result.BackupName = ""
+		result.Rollback = true
+		return result, err
+	}
+	result.ContainerId = containerId
+
+	// 同步站点记录，指向新的容器
+	siteRow, _ := dao.Site.Where(dao.Site.SiteName.Eq(siteName)).First()
+	if siteRow != nil {
+		_, _ = dao.Site.Where(dao.Site.ID.Eq(siteRow.ID)).Updates(&entity.Site{
+			Env: envOption,
+			ContainerInfo: &accessor.SiteContainerInfoOption{
+				ID: containerId,
+			},
+		})
+	}
+
+	if !keepOld {
+		LogArchive{}.ArchiveQuietly(oldInfo.ID, siteName, LogArchiveReasonRecreate)
+		err = docker.Sdk.Client.ContainerRemove(docker.Sdk.Ctx, oldInfo.ID, container.RemoveOptions{})
+		if err != nil {
+			slog.Debug("container recreate", "remove old", err.Error())
+		} else {
+			result.BackupName = ""
+		}
+	}
+	return result, nil
+}
+
+// 等待新容器稳定运行，有健康检查时等待检查通过
+func (self DockerTask) verifyContainerStart(containerId string) error {
+	startAt := time.Now()
+	for {
+		time.Sleep(time.Second)
+		info, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, containerId)
+		if err != nil {
+			return err
+		}
+		if info.State == nil {
+			return errors.New("无法获取容器状态")
+		}
+		if !info.State.Running || info.State.Restarting {
+			if info.State.Error != "" {
+				return errors.New(info.State.Error)
+			}
+			return fmt.Errorf("容器未能保持运行，退出码 %d", info.State.ExitCode)
+		}
+		if info.State.Health != nil && info.State.Health.Status == types.Unhealthy {
+			return errors.New("容器健康检查未通过")
+		}
+		healthy := info.State.Health == nil || info.State.Health.Status == types.Healthy
+		if healthy && time.Since(startAt) >= recreateStableTime {
+			return nil
+		}
+		if time.Since(startAt) >= recreateVerifyTime {
+			return errors.New("等待容器健康检查超时")
+		}
+	}
+}
+
+// 恢复旧容器的名称、重启策略及网络
+func (self DockerTask) restoreOldContainer(oldInfo types.ContainerJSON, siteName string, rename bool, start bool) {
+	if rename {
+		err := docker.Sdk.Client.ContainerRename(docker.Sdk.Ctx, oldInfo.ID, siteName)
+		if err != nil {
+			slog.Debug("container recreate", "rollback rename", err.Error())
+		}
+	}
+	_, _ = docker.Sdk.Client.ContainerUpdate(docker.Sdk.Ctx, oldInfo.ID, container.UpdateConfig{
+		RestartPolicy: oldInfo.HostConfig.RestartPolicy,
+	})
+
+	// 新容器创建时可能重建了自身网络，旧容器需要重新加入
+	currentInfo, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, oldInfo.ID)
+	if err == nil && oldInfo.NetworkSettings != nil && !oldInfo.HostConfig.NetworkMode.IsHost() {
+		for name, item := range oldInfo.NetworkSettings.Networks {
+			if _, ok := currentInfo.NetworkSettings.Networks[name]; ok {
+				continue
+			}
+			if name == siteName {
+				_, err = docker.Sdk.Client.NetworkInspect(docker.Sdk.Ctx, name, network.InspectOptions{})
+				if err != nil {
+					_, _ = docker.Sdk.Client.NetworkCreate(docker.Sdk.Ctx, name, network.CreateOptions{
+						Driver: "bridge",
+						Options: map[string]string{
+							"name": name,
+						},
+					})
+				}
+			}
+			endpointSetting := &network.EndpointSettings{
+				Aliases:    item.Aliases,
+				IPAMConfig: item.IPAMConfig,
+			}
+			err = docker.Sdk.Client.NetworkConnect(docker.Sdk.Ctx, name, oldInfo.ID, endpointSetting)
+			if err != nil {
+				slog.Debug("container recreate", "rollback network", name, "error", err.Error())
+			}
+		}
+	}
+
+	if start {
+		err = docker.Sdk.Client.ContainerStart(docker.Sdk.Ctx, oldInfo.ID, container.StartOptions{})
+		if err != nil {
+			slog.Debug("container recreate", "rollback start", err.Error())
+		}
+	}
+}
+
+// 去掉从旧镜像继承来的默认配置，使新镜像中的默认值生效
+func (self DockerTask) removeImageDefault(envOption *accessor.SiteEnvOption, oldImageId string) {
+	imageInfo, _, err := docker.Sdk.Client.ImageInspectWithRaw(docker.Sdk.Ctx, oldImageId)
+	if err != nil || imageInfo.Config == nil {
+		return
+	}
+	environment := make([]accessor.EnvItem, 0)
+	for _, item := range envOption.Environment {
+		if !function.InArray(imageInfo.Config.Env, item.Name+"="+item.Value) {
+			environment = append(environment, item)
+		}
+	}
+	envOption.Environment = environment
+
+	label := make([]accessor.EnvItem, 0)
+	for _, item := range envOption.Label {
+		if value, ok := imageInfo.Config.Labels[item.Name]; !ok || value != item.Value {
+			label = append(label, item)
+		}
+	}
+	envOption.Label = label
+
+	if envOption.Command == function.CommandJoin(imageInfo.Config.Cmd) {
+		envOption.Command = ""
+	}
+	if envOption.Entrypoint == function.CommandJoin(imageInfo.Config.Entrypoint) {
+		envOption.Entrypoint = ""
+	}
+	if envOption.WorkDir == imageInfo.Config.WorkingDir {
+		envOption.WorkDir = ""
+	}
+	if envOption.User == imageInfo.Config.User {
+		envOption.User = ""
+	}
+	if envOption.StopSignal == imageInfo.Config.StopSignal {
+		envOption.StopSignal = ""
+	}
+	if reflect.DeepEqual(envOption.Healthcheck, Site{}.GetHealthcheckItem(imageInfo.Config.Healthcheck)) {
+		envOption.Healthcheck = nil
+	}
+}
+
+// 容器使用镜像 id 创建时无法对应到仓库中的镜像
+func (self DockerTask) isImageId(imageName string) bool {
+	return imageIdRegexp.MatchString(imageName)
+}
@@ -0,0 +1,406 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/go-units"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
+	"github.com/donknap/dpanel/common/service/cron"
+	"github.com/donknap/dpanel/common/service/docker"
+	"github.com/donknap/dpanel/common/service/notice"
+	"github.com/donknap/dpanel/common/service/plugin"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	CronTypeContainerStart   = "containerStart"
+	CronTypeContainerStop    = "containerStop"
+	CronTypeContainerRestart = "containerRestart"
+	CronTypeContainerExec    = "containerExec"
+	CronTypeImagePull        = "imagePull"
+	CronTypePrune            = "prune"
+	CronTypeComposeDeploy    = "composeDeploy"
+
+	CronPruneContainer   = "container"
+	CronPruneImage       = "image"       // 只清理悬空镜像
+	CronPruneImageUnused = "imageUnused" // 清理所有未使用的镜像
+	CronPruneVolume      = "volume"
+	CronPruneNetwork     = "network"
+	CronPruneBuildCache  = "buildCache"
+
+	CronPolicySkip  = "skip"
+	CronPolicyAllow = "allow"
+
+	CronStatusRunning = "running"
+	CronStatusSuccess = "success"
+	CronStatusFailed  = "failed"
+	CronStatusSkipped = "skipped"
+	CronStatusTimeout = "timeout"
+
+	CronTriggerSchedule = "schedule"
+	CronTriggerManual   = "manual"
+
+	cronDefaultKeepLogs = 50
+	cronMaxOutputSize   = 64 * 1024
+)
+
+var (
+	cronJobs    = make(map[int32]*cronJob)
+	cronRunning = make(map[int32]int) // 正在执行的任务数量
+	cronLock    = sync.Mutex{}
+)
+
+type cronJob struct {
+	row      *entity.Cron
+	schedule *cron.Schedule
+	next     time.Time
+}
+
+type Cron struct {
+}
+
+// MonitorLoop 每秒检查一次到期的任务
+func (self Cron) MonitorLoop() {
+	// 面板重启前未结束的任务已经中断
+	_, _ = dao.CronLog.Where(dao.CronLog.Status.Eq(CronStatusRunning)).Updates(&entity.CronLog{
+		Status: CronStatusFailed,
+		Output: "面板重启，任务已中断",
+	})
+	if err := self.Reload(); err != nil {
+		slog.Debug("cron", "reload", err.Error())
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		self.dispatch(now)
+	}
+}
+
+// Reload 重新加载启用的任务，表达式没有变化的任务保留原来的下次执行时间
+func (self Cron) Reload() error {
+	list, err := dao.Cron.Where(dao.Cron.Enable.Is(true)).Find()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	cronLock.Lock()
+	defer cronLock.Unlock()
+	jobs := make(map[int32]*cronJob)
+	for _, row := range list {
+		if old, ok := cronJobs[row.ID]; ok && old.row.Expression == row.Expression {
+			old.row = row
+			jobs[row.ID] = old
+			continue
+		}
+		schedule, err := cron.Parse(row.Expression)
+		if err != nil {
+			slog.Debug("cron", "parse", row.Expression, "error", err.Error())
+			continue
+		}
+		jobs[row.ID] = &cronJob{
+			row:      row,
+			schedule: schedule,
+			next:     schedule.Next(now),
+		}
+	}
+	cronJobs = jobs
+	return nil
+}
+
+// GetNextRunAt 返回任务的下次执行时间，未启用的任务返回零值
+func (self Cron) GetNextRunAt(id int32) time.Time {
+	cronLock.Lock()
+	defer cronLock.Unlock()
+	if job, ok := cronJobs[id]; ok {
+		return job.next
+	}
+	return time.Time{}
+}
+
+func (self Cron) IsRunning(id int32) bool {
+	cronLock.Lock()
+	defer cronLock.Unlock()
+	return cronRunning[id] > 0
+}
+
+// Run 在后台执行任务并立即返回执行记录
+// 并发策略为 skip 且上次执行未结束时记录一条跳过的日志并返回错误
+func (self Cron) Run(row *entity.Cron, trigger string) (*entity.CronLog, error) {
+	if row.Setting == nil {
+		return nil, errors.New("任务配置错误")
+	}
+	logRow := &entity.CronLog{
+		CronID:      row.ID,
+		TriggerType: trigger,
+		Status:      CronStatusRunning,
+		StartAt:     time.Now(),
+	}
+
+	cronLock.Lock()
+	if cronRunning[row.ID] > 0 && row.Setting.ConcurrencyPolicy != CronPolicyAllow {
+		cronLock.Unlock()
+		logRow.Status = CronStatusSkipped
+		logRow.Output = "上次执行尚未结束，跳过本次执行"
+		logRow.EndAt = logRow.StartAt
+		_ = dao.CronLog.Create(logRow)
+		return logRow, errors.New(logRow.Output)
+	}
+	cronRunning[row.ID]++
+	cronLock.Unlock()
+
+	if err := dao.CronLog.Create(logRow); err != nil {
+		self.done(row.ID)
+		return nil, err
+	}
+
+	go func() {
+		output, timeout, err := self.execute(row.Setting, func() {
+			self.done(row.ID)
+		})
+		result := &entity.CronLog{
+			Status: CronStatusSuccess,
+			Output: output,
+			EndAt:  time.Now(),
+		}
+		result.Duration = math.Round(result.EndAt.Sub(logRow.StartAt).Seconds()*1000) / 1000
+		if err != nil {
+			result.Status = CronStatusFailed
+			if timeout {
+				result.Status = CronStatusTimeout
+			}
+			result.Output = strings.TrimSpace(output + "\n" + err.Error())
+			_ = notice.Message{}.Error("cron", row.Title, err.Error())
+		}
+		if len(result.Output) > cronMaxOutputSize {
+			result.Output = strings.ToValidUTF8(result.Output[len(result.Output)-cronMaxOutputSize:], "")
+		}
+		_, _ = dao.CronLog.Where(dao.CronLog.ID.Eq(logRow.ID)).Updates(result)
+		self.purge(row)
+	}()
+	return logRow, nil
+}
+
+// Delete 删除任务及其执行记录
+func (self Cron) Delete(id ...int32) error {
+	_, err := dao.Cron.Where(dao.Cron.ID.In(id...)).Delete()
+	if err != nil {
+		return err
+	}
+	_, err = dao.CronLog.Where(dao.CronLog.CronID.In(id...)).Delete()
+	if err != nil {
+		return err
+	}
+	return self.Reload()
+}
+
+func (self Cron) dispatch(now time.Time) {
+	due := make([]*entity.Cron, 0)
+	cronLock.Lock()
+	for _, job := range cronJobs {
+		if job.next.IsZero() || now.Before(job.next) {
+			continue
+		}
+		due = append(due, job.row)
+		job.next = job.schedule.Next(now)
+	}
+	cronLock.Unlock()
+	for _, row := range due {
+		if _, err := self.Run(row, CronTriggerSchedule); err != nil {
+			slog.Debug("cron", "run", row.Title, "error", err.Error())
+		}
+	}
+}
+
+func (self Cron) done(id int32) {
+	cronLock.Lock()
+	cronRunning[id]--
+	if cronRunning[id] <= 0 {
+		delete(cronRunning, id)
+	}
+	cronLock.Unlock()
+}
+
+// 超出保留数量的执行记录
+func (self Cron) purge(row *entity.Cron) {
+	keep := row.Setting.KeepLogs
+	if keep <= 0 {
+		keep = cronDefaultKeepLogs
+	}
+	last, _ := dao.CronLog.Where(dao.CronLog.CronID.Eq(row.ID)).Order(dao.CronLog.ID.Desc()).Offset(keep).Take()
+	if last != nil {
+		_, _ = dao.CronLog.Where(dao.CronLog.CronID.Eq(row.ID), dao.CronLog.ID.Lte(last.ID)).Delete()
+	}
+}
+
+// execute 超时后直接返回，docker 中的操作可能仍在继续
+// release 在操作真正结束后才调用，保证 skip 策略下同一任务不会重叠执行
+func (self Cron) execute(setting *accessor.CronSettingOption, release func()) (output string, timeout bool, err error) {
+	ctx, cancel := context.WithCancel(docker.Sdk.Ctx)
+	if setting.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(docker.Sdk.Ctx, time.Duration(setting.Timeout)*time.Second)
+	}
+
+	type result struct {
+		output string
+		err    error
+	}
+	resultChan := make(chan result, 1)
+	go func() {
+		defer release()
+		defer cancel()
+		output, err := self.executeType(ctx, setting)
+		resultChan <- result{output: output, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", true, fmt.Errorf("执行超时（%d 秒），任务仍在后台执行", setting.Timeout)
+		}
+		return "", false, ctx.Err()
+	case r := <-resultChan:
+		return r.output, false, r.err
+	}
+}
+
+func (self Cron) executeType(ctx context.Context, setting *accessor.CronSettingOption) (string, error) {
+	switch setting.Type {
+	case CronTypeContainerStart:
+		return "", docker.Sdk.Client.ContainerStart(ctx, setting.ContainerName, container.StartOptions{})
+	case CronTypeContainerStop:
+		return "", docker.Sdk.Client.ContainerStop(ctx, setting.ContainerName, container.StopOptions{})
+	case CronTypeContainerRestart:
+		return "", docker.Sdk.Client.ContainerRestart(ctx, setting.ContainerName, container.StopOptions{})
+	case CronTypeContainerExec:
+		return self.exec(ctx, setting)
+	case CronTypeImagePull:
+		authString, proxyList := Image{}.GetRegistryAuth(setting.ImageName)
+		proxyUrl, err := DockerTask{}.ImageRemoteWithProxy(&ImageRemoteOption{
+			Auth: authString,
+			Type: "pull",
+			Tag:  setting.ImageName,
+		}, proxyList)
+		if err != nil {
+			return "", err
+		}
+		if proxyUrl != "" {
+			return fmt.Sprintf("已通过 %s 拉取镜像 %s", proxyUrl, setting.ImageName), nil
+		}
+		return fmt.Sprintf("已拉取镜像 %s", setting.ImageName), nil
+	case CronTypePrune:
+		return self.prune(ctx, setting.PruneTarget)
+	case CronTypeComposeDeploy:
+		composeRow, _ := dao.Compose.Where(dao.Compose.ID.Eq(setting.ComposeId)).First()
+		if composeRow == nil {
+			return "", errors.New("Compose 任务不存在")
+		}
+		tasker, err := Compose{}.GetTasker(composeRow)
+		if err != nil {
+			return "", err
+		}
+		return "", tasker.Deploy()
+	}
+	return "", fmt.Errorf("unknown cron type %s", setting.Type)
+}
+
+func (self Cron) exec(ctx context.Context, setting *accessor.CronSettingOption) (string, error) {
+	output := &cronOutput{}
+	result, err := plugin.Command{}.Exec(ctx, &plugin.ExecOption{
+		ContainerName: setting.ContainerName,
+		Cmd:           function.CommandSplit(setting.Command),
+		WorkDir:       setting.WorkDir,
+		Output:        output,
+	})
+	if err != nil {
+		return output.String(), err
+	}
+	if result.ExitCode != 0 {
+		return output.String(), fmt.Errorf("命令退出码 %d", result.ExitCode)
+	}
+	return output.String(), nil
+}
+
+func (self Cron) prune(ctx context.Context, target []string) (string, error) {
+	if function.IsEmptyArray(target) {
+		return "", errors.New("请选择需要清理的资源")
+	}
+	output := make([]string, 0)
+	for _, item := range target {
+		var deleted int
+		var reclaimed uint64
+		var err error
+		switch item {
+		case CronPruneContainer:
+			var report container.PruneReport
+			report, err = docker.Sdk.Client.ContainersPrune(ctx, filters.NewArgs())
+			deleted, reclaimed = len(report.ContainersDeleted), report.SpaceReclaimed
+		case CronPruneImage, CronPruneImageUnused:
+			filter := filters.NewArgs()
+			if item == CronPruneImageUnused {
+				filter.Add("dangling", "0")
+			}
+			var report image.PruneReport
+			report, err = docker.Sdk.Client.ImagesPrune(ctx, filter)
+			deleted, reclaimed = len(report.ImagesDeleted), report.SpaceReclaimed
+		case CronPruneVolume:
+			var report volume.PruneReport
+			report, err = docker.Sdk.Client.VolumesPrune(ctx, filters.NewArgs())
+			deleted, reclaimed = len(report.VolumesDeleted), report.SpaceReclaimed
+		case CronPruneNetwork:
+			var report network.PruneReport
+			report, err = docker.Sdk.Client.NetworksPrune(ctx, filters.NewArgs())
+			deleted = len(report.NetworksDeleted)
+		case CronPruneBuildCache:
+			var report *types.BuildCachePruneReport
+			report, err = docker.Sdk.Client.BuildCachePrune(ctx, types.BuildCachePruneOptions{
+				All: true,
+			})
+			if report != nil {
+				deleted, reclaimed = len(report.CachesDeleted), report.SpaceReclaimed
+			}
+		default:
+			err = fmt.Errorf("unknown prune target %s", item)
+		}
+		if err != nil {
+			return strings.Join(output, "\n"), err
+		}
+		output = append(output, fmt.Sprintf("%s: 删除 %d 个，释放 %s", item, deleted, units.HumanSize(float64(reclaimed))))
+	}
+	return strings.Join(output, "\n"), nil
+}
+
+// cronOutput 按输出顺序保存 stdout 与 stderr，只保留最后的内容
+type cronOutput struct {
+	buffer bytes.Buffer
+	lock   sync.Mutex
+}
+
+func (self *cronOutput) Write(p []byte) (int, error) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.buffer.Write(p)
+	if self.buffer.Len() > cronMaxOutputSize*2 {
+		self.buffer.Next(self.buffer.Len() - cronMaxOutputSize)
+	}
+	return len(p), nil
+}
+
+func (self *cronOutput) String() string {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	return strings.TrimSpace(strings.ToValidUTF8(self.buffer.String(), ""))
+}
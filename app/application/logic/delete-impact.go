@@ -0,0 +1,334 @@
+package logic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
+	"github.com/donknap/dpanel/common/service/docker"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DeleteTargetContainer = "container"
+	DeleteTargetVolume    = "volume"
+	DeleteTargetNetwork   = "network"
+
+	deleteConfirmExpire = 5 * time.Minute
+)
+
+var (
+	ErrDeleteNotConfirmed = errors.New("删除前需要确认影响范围，请先获取影响报告")
+
+	deleteConfirmCollect = make(map[string]*deleteConfirm)
+	deleteConfirmLock    = sync.Mutex{}
+)
+
+type deleteConfirm struct {
+	digest   string
+	expireAt time.Time
+}
+
+type DeleteImpactSite struct {
+	Id        int32  `json:"id"`
+	SiteTitle string `json:"siteTitle"`
+	SiteName  string `json:"siteName"`
+}
+
+type DeleteImpactDomain struct {
+	Id            int32  `json:"id"`
+	ServerName    string `json:"serverName"`
+	ContainerName string `json:"containerName"`
+}
+
+type DeleteImpactBackup struct {
+	Id            int32  `json:"id"`
+	ContainerName string `json:"containerName"`
+	BackupTar     string `json:"backupTar"`
+}
+
+type DeleteImpactItem struct {
+	Name       string                `json:"name"`
+	Containers []string              `json:"containers"` // 依赖或是正在使用该资源的容器
+	Compose    []string              `json:"compose"`
+	Sites      []*DeleteImpactSite   `json:"sites"`
+	Domains    []*DeleteImpactDomain `json:"domains"`
+	Backups    []*DeleteImpactBackup `json:"backups"`
+}
+
+type DeleteImpactResult struct {
+	Type         string              `json:"type"`
+	List         []*DeleteImpactItem `json:"list"`
+	ConfirmToken string              `json:"confirmToken"`
+	ExpireAt     time.Time           `json:"expireAt"`
+}
+
+type DeleteImpact struct {
+}
+
+// Report 获取影响报告并生成确认码，删除时需要带上确认码
+func (self DeleteImpact) Report(target string, nameList []string) (*DeleteImpactResult, error) {
+	result, err := self.Analyze(target, nameList)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := self.digest(result)
+	if err != nil {
+		return nil, err
+	}
+	deleteConfirmLock.Lock()
+	defer deleteConfirmLock.Unlock()
+	self.expire()
+	result.ConfirmToken = function.GetRandomString(32)
+	result.ExpireAt = time.Now().Add(deleteConfirmExpire)
+	deleteConfirmCollect[result.ConfirmToken] = &deleteConfirm{
+		digest:   digest,
+		expireAt: result.ExpireAt,
+	}
+	return result, nil
+}
+
+// Confirm 校验删除确认码，确认码只能使用一次
+// 确认码无效、过期或是影响范围发生变化时返回 ErrDeleteNotConfirmed，需要重新获取影响报告
+func (self DeleteImpact) Confirm(target string, nameList []string, token string) error {
+	if token == "" {
+		return ErrDeleteNotConfirmed
+	}
+	result, err := self.Analyze(target, nameList)
+	if err != nil {
+		return err
+	}
+	digest, err := self.digest(result)
+	if err != nil {
+		return err
+	}
+	deleteConfirmLock.Lock()
+	defer deleteConfirmLock.Unlock()
+	self.expire()
+	item, ok := deleteConfirmCollect[token]
+	if !ok || item.digest != digest {
+		return ErrDeleteNotConfirmed
+	}
+	delete(deleteConfirmCollect, token)
+	return nil
+}
+
+// Analyze 获取删除资源前会受到影响的容器、站点、域名及备份
+func (self DeleteImpact) Analyze(target string, nameList []string) (*DeleteImpactResult, error) {
+	if function.IsEmptyArray(nameList) {
+		return nil, errors.New("请选择需要删除的资源")
+	}
+	result := &DeleteImpactResult{
+		Type: target,
+		List: make([]*DeleteImpactItem, 0, len(nameList)),
+	}
+	for _, name := range nameList {
+		var item *DeleteImpactItem
+		var err error
+		switch target {
+		case DeleteTargetContainer:
+			item, err = self.container(name)
+		case DeleteTargetVolume:
+			item, err = self.volume(name)
+		case DeleteTargetNetwork:
+			item, err = self.network(name)
+		default:
+			err = errors.New("不支持的资源类型 " + target)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.List = append(result.List, item)
+	}
+	// 同一批资源可以通过 id 或名称以任意顺序指定
+	sort.Slice(result.List, func(i, j int) bool {
+		return result.List[i].Name < result.List[j].Name
+	})
+	return result, nil
+}
+
+// CleanContainer 清理容器在面板中遗留的域名配置及站点记录
+// 域名中保存的是容器名称，旧数据可能保存的是容器 id，这里同时清理
+func (self DeleteImpact) CleanContainer(containerName string, containerId string) {
+	domainList, _ := dao.SiteDomain.Where(dao.SiteDomain.ContainerID.In(containerName, containerId)).Find()
+	for _, domain := range domainList {
+		Site{}.GetSiteNginxSetting(domain.ServerName).RemoveAll()
+	}
+	_, _ = dao.SiteDomain.Where(dao.SiteDomain.ContainerID.In(containerName, containerId)).Delete()
+	_, _ = dao.Site.Where(dao.Site.SiteName.Eq(containerName)).
+		Or(dao.Site.ContainerInfo.Eq(&accessor.SiteContainerInfoOption{
+			ID: containerId,
+		})).Delete()
+}
+
+func (self DeleteImpact) container(md5 string) (*DeleteImpactItem, error) {
+	containerInfo, err := docker.Sdk.ContainerInfo(md5)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := NewContainerGraph().Build(&ContainerGraphOption{
+		Md5: containerInfo.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	item := &DeleteImpactItem{
+		Name:       containerInfo.Name,
+		Containers: graph.Dependents,
+		Compose:    make([]string, 0),
+	}
+	if item.Containers == nil {
+		item.Containers = make([]string, 0)
+	}
+	if project := containerInfo.Config.Labels[ComposeProjectLabel]; project != "" {
+		item.Compose = append(item.Compose, project)
+	}
+	siteList, _ := dao.Site.Where(dao.Site.SiteName.Eq(containerInfo.Name)).
+		Or(dao.Site.ContainerInfo.Eq(&accessor.SiteContainerInfoOption{
+			ID: containerInfo.ID,
+		})).Find()
+	item.Sites = self.toSite(siteList)
+	item.Domains = self.domain([]string{containerInfo.Name, containerInfo.ID})
+	item.Backups = self.backup([]string{containerInfo.Name})
+	return item, nil
+}
+
+func (self DeleteImpact) volume(name string) (*DeleteImpactItem, error) {
+	volumeInfo, err := docker.Sdk.Client.VolumeInspect(docker.Sdk.Ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	containerList, err := docker.Sdk.Client.ContainerList(docker.Sdk.Ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("volume", volumeInfo.Name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	item := &DeleteImpactItem{
+		Name:       volumeInfo.Name,
+		Containers: make([]string, 0, len(containerList)),
+		Compose:    make([]string, 0),
+	}
+	if project := volumeInfo.Labels[ComposeProjectLabel]; project != "" {
+		item.Compose = append(item.Compose, project)
+	}
+	for _, row := range containerList {
+		item.Containers = append(item.Containers, strings.TrimPrefix(row.Names[0], "/"))
+		if project := row.Labels[ComposeProjectLabel]; project != "" && !function.InArray(item.Compose, project) {
+			item.Compose = append(item.Compose, project)
+		}
+	}
+	sort.Strings(item.Containers)
+	item.Sites = self.site(item.Containers)
+	item.Domains = self.domain(item.Containers)
+	item.Backups = self.backup(item.Containers)
+	return item, nil
+}
+
+func (self DeleteImpact) network(name string) (*DeleteImpactItem, error) {
+	networkInfo, err := docker.Sdk.Client.NetworkInspect(docker.Sdk.Ctx, name, network.InspectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	item := &DeleteImpactItem{
+		Name:       networkInfo.Name,
+		Containers: make([]string, 0, len(networkInfo.Containers)),
+		Compose:    make([]string, 0),
+		Backups:    make([]*DeleteImpactBackup, 0),
+	}
+	if project := networkInfo.Labels[ComposeProjectLabel]; project != "" {
+		item.Compose = append(item.Compose, project)
+	}
+	for _, row := range networkInfo.Containers {
+		item.Containers = append(item.Containers, row.Name)
+	}
+	sort.Strings(item.Containers)
+	// 面板创建站点时会创建与站点同名的网络
+	item.Sites = self.site(append([]string{networkInfo.Name}, item.Containers...))
+	item.Domains = self.domain(item.Containers)
+	return item, nil
+}
+
+func (self DeleteImpact) site(nameList []string) []*DeleteImpactSite {
+	if function.IsEmptyArray(nameList) {
+		return make([]*DeleteImpactSite, 0)
+	}
+	siteList, _ := dao.Site.Where(dao.Site.SiteName.In(nameList...)).Find()
+	return self.toSite(siteList)
+}
+
+func (self DeleteImpact) toSite(siteList []*entity.Site) []*DeleteImpactSite {
+	result := make([]*DeleteImpactSite, 0, len(siteList))
+	for _, row := range siteList {
+		result = append(result, &DeleteImpactSite{
+			Id:        row.ID,
+			SiteTitle: row.SiteTitle,
+			SiteName:  row.SiteName,
+		})
+	}
+	return result
+}
+
+func (self DeleteImpact) domain(containerList []string) []*DeleteImpactDomain {
+	result := make([]*DeleteImpactDomain, 0)
+	if function.IsEmptyArray(containerList) {
+		return result
+	}
+	domainList, _ := dao.SiteDomain.Where(dao.SiteDomain.ContainerID.In(containerList...)).Order(dao.SiteDomain.ID.Asc()).Find()
+	for _, row := range domainList {
+		result = append(result, &DeleteImpactDomain{
+			Id:            row.ID,
+			ServerName:    row.ServerName,
+			ContainerName: row.ContainerID,
+		})
+	}
+	return result
+}
+
+func (self DeleteImpact) backup(containerList []string) []*DeleteImpactBackup {
+	result := make([]*DeleteImpactBackup, 0)
+	if function.IsEmptyArray(containerList) {
+		return result
+	}
+	backupList, _ := dao.Backup.Where(dao.Backup.ContainerID.In(containerList...)).Order(dao.Backup.ID.Asc()).Find()
+	for _, row := range backupList {
+		backupItem := &DeleteImpactBackup{
+			Id:            row.ID,
+			ContainerName: row.ContainerID,
+		}
+		if row.Setting != nil {
+			backupItem.BackupTar = row.Setting.BackupTar
+		}
+		result = append(result, backupItem)
+	}
+	return result
+}
+
+func (self DeleteImpact) expire() {
+	for key, item := range deleteConfirmCollect {
+		if time.Now().After(item.expireAt) {
+			delete(deleteConfirmCollect, key)
+		}
+	}
+}
+
+// digest 影响范围的摘要，确认期间影响范围发生变化时确认码失效
+func (self DeleteImpact) digest(result *DeleteImpactResult) (string, error) {
+	content, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:]), nil
+}
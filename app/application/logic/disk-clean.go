@@ -0,0 +1,34 @@
+package logic
+
+import (
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+)
+
+// DiskClean 执行某一项磁盘清理建议，容器通过 ContainerDelete 删除，同时清理面板中的站点及域名
+func (self DockerTask) DiskClean(recommend *commonLogic.DiskCleanRecommend) *commonLogic.DiskCleanResult {
+	result := &commonLogic.DiskCleanResult{
+		Type:    recommend.Type,
+		Removed: make([]string, 0),
+		Error:   make(map[string]string),
+	}
+	for _, item := range recommend.Items {
+		var err error
+		if recommend.Type == commonLogic.DiskCleanStoppedContainer {
+			_, err = self.ContainerDelete(&ContainerDeleteOption{
+				Md5: item.Id,
+			})
+		} else {
+			err = commonLogic.DiskUsage{}.Remove(recommend.Type, item)
+		}
+		if err != nil {
+			result.Error[item.Name] = err.Error()
+			continue
+		}
+		result.Removed = append(result.Removed, item.Name)
+		result.Reclaimed += item.Size
+	}
+	go func() {
+		_, _ = commonLogic.DiskUsage{}.Refresh()
+	}()
+	return result
+}
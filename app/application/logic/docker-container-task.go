@@ -8,6 +8,7 @@ import (
 	"github.com/donknap/dpanel/common/function"
 	"github.com/donknap/dpanel/common/service/docker"
 	"github.com/donknap/dpanel/common/service/notice"
+	"time"
 )
 
 func (self DockerTask) ContainerCreate(task *CreateContainerOption) (string, error) {
@@ -96,7 +97,7 @@ func (self DockerTask) ContainerCreate(task *CreateContainerOption) (string, err
 			if value.Host == "" || value.Dest == "" {
 				continue
 			}
-			builder.WithVolume(value.Host, value.Dest, value.Permission == "readonly")
+			builder.WithVolume(value.Host, value.Dest, value.Permission == "readonly" || value.Permission == "read")
 		}
 	}
 
@@ -135,7 +136,7 @@ func (self DockerTask) ContainerCreate(task *CreateContainerOption) (string, err
 	}
 
 	if task.BuildParams.User != "" {
-		builder.WithWorkDir(task.BuildParams.WorkDir)
+		builder.WithUser(task.BuildParams.User)
 	}
 
 	if task.BuildParams.Command != "" {
@@ -168,6 +169,80 @@ func (self DockerTask) ContainerCreate(task *CreateContainerOption) (string, err
 		builder.WithExtraHosts(item.Name, item.Value)
 	}
 
+	if task.BuildParams.Healthcheck != nil && task.BuildParams.Healthcheck.Test != "" {
+		healthcheck := task.BuildParams.Healthcheck
+		test := []string{"NONE"}
+		switch healthcheck.ShellType {
+		case "CMD":
+			test = append([]string{"CMD"}, function.CommandSplit(healthcheck.Test)...)
+		case "NONE":
+		default:
+			test = []string{"CMD-SHELL", healthcheck.Test}
+		}
+		builder.WithHealthcheck(
+			test,
+			time.Duration(healthcheck.Interval)*time.Second,
+			time.Duration(healthcheck.Timeout)*time.Second,
+			time.Duration(healthcheck.StartPeriod)*time.Second,
+			healthcheck.Retries,
+		)
+	}
+
+	if !function.IsEmptyArray(task.BuildParams.CapAdd) {
+		builder.WithCapAdd(task.BuildParams.CapAdd...)
+	}
+	if !function.IsEmptyArray(task.BuildParams.CapDrop) {
+		builder.WithCapDrop(task.BuildParams.CapDrop...)
+	}
+
+	for _, item := range task.BuildParams.Devices {
+		if item.Host == "" {
+			continue
+		}
+		builder.WithDevice(item.Host, item.Dest, item.Permission)
+	}
+
+	for _, item := range task.BuildParams.Ulimits {
+		if item.Name == "" {
+			continue
+		}
+		builder.WithUlimit(item.Name, item.Soft, item.Hard)
+	}
+
+	for _, item := range task.BuildParams.Sysctls {
+		if item.Name == "" {
+			continue
+		}
+		builder.WithSysctl(item.Name, item.Value)
+	}
+
+	if !function.IsEmptyArray(task.BuildParams.SecurityOpt) {
+		builder.WithSecurityOpt(task.BuildParams.SecurityOpt...)
+	}
+
+	for _, item := range task.BuildParams.Tmpfs {
+		if item.Name == "" {
+			continue
+		}
+		builder.WithTmpfs(item.Name, item.Value)
+	}
+
+	if task.BuildParams.Init {
+		builder.WithInit()
+	}
+
+	if task.BuildParams.StopSignal != "" {
+		builder.WithStopSignal(task.BuildParams.StopSignal)
+	}
+
+	if task.BuildParams.StopTimeout > 0 {
+		builder.WithStopTimeout(task.BuildParams.StopTimeout)
+	}
+
+	if task.BuildParams.Hostname != "" {
+		builder.WithHostname(task.BuildParams.Hostname)
+	}
+
 	response, err := builder.Execute()
 	if err != nil {
 		return "", err
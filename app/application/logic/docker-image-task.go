@@ -6,6 +6,7 @@ import (
 	"github.com/donknap/dpanel/common/accessor"
 	"github.com/donknap/dpanel/common/dao"
 	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
 	"github.com/donknap/dpanel/common/service/docker"
 	"github.com/donknap/dpanel/common/service/notice"
 	"io"
@@ -75,6 +76,30 @@ func (self DockerTask) ImageBuild(buildImageTask *BuildImageOption) error {
 	return nil
 }
 
+// ImageRemoteWithProxy 如果仓库采用了代理地址，则优先使用，代理地址无法拉取后，尝试用原始的地址拉取
+func (self DockerTask) ImageRemoteWithProxy(task *ImageRemoteOption, proxyList []string) (proxyUrl string, err error) {
+	if function.IsEmptyArray(proxyList) {
+		return "", self.ImageRemote(task)
+	}
+	tagDetail := Image{}.GetImageTagDetail(task.Tag)
+	proxyList = append(proxyList, tagDetail.Registry)
+	for _, value := range proxyList {
+		proxyImageTag := Image{}.GetProxyImageTag(value, task.Tag)
+		err = self.ImageRemote(&ImageRemoteOption{
+			Auth:     task.Auth,
+			Type:     task.Type,
+			Tag:      proxyImageTag,
+			Platform: task.Platform,
+		})
+		if err == nil {
+			// 如果使用了加速，需要给镜像 tag 一个原来的名称
+			_ = docker.Sdk.Client.ImageTag(docker.Sdk.Ctx, proxyImageTag, task.Tag)
+			return value, nil
+		}
+	}
+	return "", err
+}
+
 func (self DockerTask) ImageRemote(task *ImageRemoteOption) error {
 	var err error
 	var out io.ReadCloser
@@ -121,10 +146,10 @@ func (self DockerTask) ImageRemote(task *ImageRemoteOption) error {
 				}
 				if pd.Status == "Pull complete" {
 					pg[pd.Id].Extracting = 100
-					docker.QueueDockerImageDownloadMessage <- pg
+					self.pushDownloadProgress(pg)
 				}
 				if pd.ProgressDetail.Total > 0 {
-					docker.QueueDockerImageDownloadMessage <- pg
+					self.pushDownloadProgress(pg)
 				}
 			}
 			if message.Err != nil {
@@ -133,3 +158,11 @@ func (self DockerTask) ImageRemote(task *ImageRemoteOption) error {
 		}
 	}
 }
+
+// 后台任务拉取镜像时可能没有 ws 连接消费进度，队列满时丢弃
+func (self DockerTask) pushDownloadProgress(pg map[string]*docker.ProgressDownloadImage) {
+	select {
+	case docker.QueueDockerImageDownloadMessage <- pg:
+	default:
+	}
+}
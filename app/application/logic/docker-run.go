@@ -0,0 +1,705 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/docker/go-units"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/function"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type DockerRunParseResult struct {
+	SiteName string                  `json:"siteName"`
+	Option   *accessor.SiteEnvOption `json:"option"`
+	Unmapped []string                `json:"unmapped"` // 无法转换到表单的参数
+}
+
+// 需要携带参数值的 docker run 参数，包含暂时不支持转换的参数，用于正确跳过参数值
+var dockerRunValueFlag = map[string]string{
+	"-a": "--attach", "-c": "--cpu-shares", "-e": "--env", "-h": "--hostname", "-l": "--label",
+	"-m": "--memory", "-p": "--publish", "-u": "--user", "-v": "--volume", "-w": "--workdir",
+	"--add-host": "", "--annotation": "", "--attach": "", "--blkio-weight": "", "--cap-add": "", "--cap-drop": "",
+	"--cgroup-parent": "", "--cgroupns": "", "--cidfile": "", "--cpu-period": "", "--cpu-quota": "",
+	"--cpu-rt-period": "", "--cpu-rt-runtime": "", "--cpu-shares": "", "--cpus": "", "--cpuset-cpus": "",
+	"--cpuset-mems": "", "--detach-keys": "", "--device": "", "--device-cgroup-rule": "", "--dns": "",
+	"--dns-option": "", "--dns-search": "", "--domainname": "", "--entrypoint": "", "--env": "",
+	"--env-file": "", "--expose": "", "--gpus": "", "--group-add": "", "--health-cmd": "",
+	"--health-interval": "", "--health-retries": "", "--health-start-period": "", "--health-start-interval": "",
+	"--health-timeout": "", "--hostname": "", "--ip": "", "--ip6": "", "--ipc": "", "--isolation": "",
+	"--label": "", "--label-file": "", "--link": "", "--link-local-ip": "", "--log-driver": "", "--log-opt": "",
+	"--mac-address": "", "--memory": "", "--memory-reservation": "", "--memory-swap": "",
+	"--memory-swappiness": "", "--mount": "", "--name": "", "--net": "", "--net-alias": "", "--network": "",
+	"--network-alias": "", "--oom-score-adj": "", "--pid": "", "--pids-limit": "", "--platform": "",
+	"--publish": "", "--pull": "", "--restart": "", "--runtime": "", "--security-opt": "", "--shm-size": "",
+	"--stop-signal": "", "--stop-timeout": "", "--storage-opt": "", "--sysctl": "", "--tmpfs": "",
+	"--ulimit": "", "--user": "", "--userns": "", "--uts": "", "--volume": "", "--volume-driver": "",
+	"--volumes-from": "", "--workdir": "",
+}
+
+// 不需要转换的参数，面板创建的容器默认后台运行
+var dockerRunIgnoreFlag = []string{
+	"-d", "--detach", "-i", "--interactive", "-t", "--tty",
+}
+
+type DockerRun struct {
+}
+
+// Parse 解析 docker run 命令，转换为创建容器时的表单配置
+func (self DockerRun) Parse(command string) (*DockerRunParseResult, error) {
+	args, err := function.CommandSplitStrict(command)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 && args[0] == "sudo" {
+		args = args[1:]
+	}
+	if len(args) >= 2 && args[0] == "docker" && args[1] == "run" {
+		args = args[2:]
+	} else if len(args) >= 3 && args[0] == "docker" && args[1] == "container" && args[2] == "run" {
+		args = args[3:]
+	} else {
+		return nil, errors.New("请输入以 docker run 开头的命令")
+	}
+
+	result := &DockerRunParseResult{
+		Option:   &accessor.SiteEnvOption{},
+		Unmapped: make([]string, 0),
+	}
+	option := result.Option
+	var networkAlias []string
+	var ipV4, ipV6 string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			// 第一个非参数为镜像，之后都是容器的命令
+			option.ImageName = arg
+			if i+1 < len(args) {
+				option.Command = function.CommandJoin(args[i+1:])
+			}
+			break
+		}
+		if arg == "--" {
+			continue
+		}
+
+		name, value, hasValue := self.parseFlag(arg)
+		// 合并在一起的短参数，例如 -itd
+		if !hasValue && !strings.HasPrefix(name, "--") && len(name) > 2 {
+			expand := make([]string, 0)
+			for _, c := range name[1:] {
+				expand = append(expand, "-"+string(c))
+			}
+			args = append(append(append([]string{}, args[:i]...), expand...), args[i+1:]...)
+			i--
+			continue
+		}
+		if full, ok := dockerRunValueFlag[name]; ok && full != "" {
+			name = full
+		}
+		if _, ok := dockerRunValueFlag[name]; ok && !hasValue {
+			if i+1 >= len(args) {
+				result.Unmapped = append(result.Unmapped, arg)
+				break
+			}
+			i++
+			value = args[i]
+		}
+
+		mapped := true
+		switch name {
+		case "--name":
+			result.SiteName = value
+			option.Name = value
+		case "--env":
+			envName, envValue, _ := strings.Cut(value, "=")
+			option.Environment = append(option.Environment, accessor.EnvItem{
+				Name:  envName,
+				Value: envValue,
+			})
+		case "--publish":
+			port, ok := self.parsePort(value)
+			if ok {
+				option.Ports = append(option.Ports, port)
+			} else {
+				mapped = false
+			}
+		case "-P", "--publish-all":
+			option.PublishAllPorts = true
+		case "--volume":
+			mapped = self.parseVolume(option, value)
+		case "--mount":
+			mapped = self.parseMount(option, value)
+		case "--volumes-from":
+			option.Links = append(option.Links, accessor.LinkItem{
+				Name:   value,
+				Alise:  value,
+				Volume: true,
+			})
+		case "--link":
+			linkName, linkAlias, _ := strings.Cut(value, ":")
+			if linkAlias == "" {
+				linkAlias = linkName
+			}
+			option.Links = append(option.Links, accessor.LinkItem{
+				Name:  linkName,
+				Alise: linkAlias,
+			})
+		case "--network", "--net":
+			switch value {
+			case "host":
+				option.UseHostNetwork = true
+			case "bridge", "default":
+			default:
+				if value == "none" || strings.HasPrefix(value, "container:") {
+					mapped = false
+				} else {
+					option.Network = append(option.Network, accessor.NetworkItem{
+						Name: value,
+					})
+				}
+			}
+		case "--network-alias", "--net-alias":
+			networkAlias = append(networkAlias, value)
+		case "--ip":
+			ipV4 = value
+		case "--ip6":
+			ipV6 = value
+		case "--restart":
+			option.Restart, _, _ = strings.Cut(value, ":")
+		case "--label":
+			labelName, labelValue, _ := strings.Cut(value, "=")
+			option.Label = append(option.Label, accessor.EnvItem{
+				Name:  labelName,
+				Value: labelValue,
+			})
+		case "--cpus":
+			cpus, err := strconv.ParseFloat(value, 32)
+			if err == nil {
+				option.Cpus = float32(cpus)
+			} else {
+				mapped = false
+			}
+		case "--memory":
+			memory, err := units.RAMInBytes(value)
+			if err == nil {
+				option.Memory = int(memory / 1024 / 1024)
+			} else {
+				mapped = false
+			}
+		case "--shm-size":
+			option.ShmSize = value
+		case "--workdir":
+			option.WorkDir = value
+		case "--user":
+			option.User = value
+		case "--entrypoint":
+			option.Entrypoint = value
+		case "--privileged":
+			option.Privileged = true
+		case "--rm":
+			option.AutoRemove = true
+		case "--dns":
+			option.Dns = append(option.Dns, value)
+		case "--add-host":
+			host, ip, ok := strings.Cut(value, ":")
+			if !ok {
+				host, ip, ok = strings.Cut(value, "=")
+			}
+			if ok {
+				option.ExtraHosts = append(option.ExtraHosts, accessor.EnvItem{
+					Name:  host,
+					Value: ip,
+				})
+			} else {
+				mapped = false
+			}
+		case "--log-driver":
+			option.Log.Driver = value
+		case "--log-opt":
+			optName, optValue, _ := strings.Cut(value, "=")
+			switch optName {
+			case "max-size":
+				option.Log.MaxSize = optValue
+			case "max-file":
+				option.Log.MaxFile = optValue
+			default:
+				mapped = false
+			}
+		case "--health-cmd":
+			self.healthcheck(option).Test = value
+		case "--health-interval", "--health-timeout", "--health-start-period":
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				mapped = false
+				break
+			}
+			healthcheck := self.healthcheck(option)
+			switch name {
+			case "--health-interval":
+				healthcheck.Interval = int(duration.Seconds())
+			case "--health-timeout":
+				healthcheck.Timeout = int(duration.Seconds())
+			case "--health-start-period":
+				healthcheck.StartPeriod = int(duration.Seconds())
+			}
+		case "--health-retries":
+			retries, err := strconv.Atoi(value)
+			if err == nil {
+				self.healthcheck(option).Retries = retries
+			} else {
+				mapped = false
+			}
+		case "--no-healthcheck":
+			option.Healthcheck = &accessor.HealthcheckItem{
+				ShellType: "NONE",
+				Test:      "NONE",
+			}
+		case "--cap-add":
+			option.CapAdd = append(option.CapAdd, value)
+		case "--cap-drop":
+			option.CapDrop = append(option.CapDrop, value)
+		case "--device":
+			temp := strings.Split(value, ":")
+			device := accessor.DeviceItem{
+				Host: temp[0],
+			}
+			if len(temp) > 1 {
+				device.Dest = temp[1]
+			}
+			if len(temp) > 2 {
+				device.Permission = temp[2]
+			}
+			option.Devices = append(option.Devices, device)
+		case "--ulimit":
+			ulimit, err := units.ParseUlimit(value)
+			if err == nil {
+				option.Ulimits = append(option.Ulimits, accessor.UlimitItem{
+					Name: ulimit.Name,
+					Soft: ulimit.Soft,
+					Hard: ulimit.Hard,
+				})
+			} else {
+				mapped = false
+			}
+		case "--sysctl":
+			sysctlName, sysctlValue, _ := strings.Cut(value, "=")
+			option.Sysctls = append(option.Sysctls, accessor.EnvItem{
+				Name:  sysctlName,
+				Value: sysctlValue,
+			})
+		case "--security-opt":
+			option.SecurityOpt = append(option.SecurityOpt, value)
+		case "--tmpfs":
+			path, tmpfsOption, _ := strings.Cut(value, ":")
+			option.Tmpfs = append(option.Tmpfs, accessor.EnvItem{
+				Name:  path,
+				Value: tmpfsOption,
+			})
+		case "--init":
+			option.Init = true
+		case "--stop-signal":
+			option.StopSignal = value
+		case "--stop-timeout":
+			timeout, err := strconv.Atoi(value)
+			if err == nil {
+				option.StopTimeout = timeout
+			} else {
+				mapped = false
+			}
+		case "--hostname":
+			option.Hostname = value
+		default:
+			for _, item := range dockerRunIgnoreFlag {
+				if item == name {
+					mapped = true
+					break
+				}
+				mapped = false
+			}
+		}
+		if !mapped {
+			if _, ok := dockerRunValueFlag[name]; ok && !hasValue {
+				result.Unmapped = append(result.Unmapped, arg+" "+value)
+			} else {
+				result.Unmapped = append(result.Unmapped, arg)
+			}
+		}
+	}
+
+	if option.ImageName == "" {
+		return nil, errors.New("命令中没有指定镜像")
+	}
+
+	// 别名及固定 ip 需要指定到自定义网络上
+	if len(networkAlias) > 0 || ipV4 != "" || ipV6 != "" {
+		if len(option.Network) > 0 {
+			option.Network[0].Alise = networkAlias
+			option.Network[0].IpV4 = ipV4
+			option.Network[0].IpV6 = ipV6
+		} else {
+			for _, item := range networkAlias {
+				result.Unmapped = append(result.Unmapped, "--network-alias "+item)
+			}
+			if ipV4 != "" {
+				result.Unmapped = append(result.Unmapped, "--ip "+ipV4)
+			}
+			if ipV6 != "" {
+				result.Unmapped = append(result.Unmapped, "--ip6 "+ipV6)
+			}
+		}
+	}
+	if option.Healthcheck != nil && option.Healthcheck.ShellType == "" {
+		option.Healthcheck.ShellType = "CMD-SHELL"
+	}
+	return result, nil
+}
+
+func (self DockerRun) parseFlag(arg string) (name string, value string, hasValue bool) {
+	if strings.HasPrefix(arg, "--") {
+		return strings.Cut(arg, "=")
+	}
+	// 短参数的值可以直接跟在后面，例如 -p80:80 -eKEY=VALUE
+	if len(arg) > 2 {
+		if _, ok := dockerRunValueFlag[arg[:2]]; ok {
+			return arg[:2], strings.TrimPrefix(arg[2:], "="), true
+		}
+	}
+	return arg, "", false
+}
+
+// 格式为 [ip:][hostPort:]containerPort[/protocol]
+func (self DockerRun) parsePort(value string) (accessor.PortItem, bool) {
+	port := accessor.PortItem{}
+	protocol := ""
+	if index := strings.LastIndex(value, "/"); index > 0 {
+		protocol = value[index+1:]
+		value = value[:index]
+	}
+	// ipv6 地址使用 [] 包裹
+	if strings.HasPrefix(value, "[") {
+		end := strings.Index(value, "]")
+		if end < 0 {
+			return port, false
+		}
+		port.HostIp = value[1:end]
+		value = strings.TrimPrefix(value[end+1:], ":")
+	}
+	temp := strings.Split(value, ":")
+	switch len(temp) {
+	case 1:
+		port.Dest = temp[0]
+	case 2:
+		port.Host, port.Dest = temp[0], temp[1]
+	case 3:
+		port.HostIp, port.Host, port.Dest = temp[0], temp[1], temp[2]
+	default:
+		return port, false
+	}
+	if port.Dest == "" {
+		return port, false
+	}
+	if protocol != "" {
+		port.Protocol = protocol
+		port.Dest += "/" + protocol
+	}
+	return port, true
+}
+
+// 格式为 [host:]container[:options]
+func (self DockerRun) parseVolume(option *accessor.SiteEnvOption, value string) bool {
+	temp := strings.Split(value, ":")
+	if len(temp) == 1 {
+		option.VolumesDefault = append(option.VolumesDefault, accessor.VolumeItem{
+			Dest: temp[0],
+		})
+		return true
+	}
+	item := accessor.VolumeItem{
+		Host:       temp[0],
+		Dest:       temp[1],
+		Permission: "write",
+	}
+	if len(temp) > 2 {
+		for _, opt := range strings.Split(temp[2], ",") {
+			if opt == "ro" || opt == "readonly" {
+				item.Permission = "read"
+			}
+		}
+	}
+	option.Volumes = append(option.Volumes, item)
+	return true
+}
+
+// 格式为 type=bind,source=/a,target=/b,readonly
+func (self DockerRun) parseMount(option *accessor.SiteEnvOption, value string) bool {
+	mountType, source, target, readonly := "volume", "", "", false
+	tmpfsOption := make([]string, 0)
+	for _, field := range strings.Split(value, ",") {
+		key, val, _ := strings.Cut(field, "=")
+		switch key {
+		case "type":
+			mountType = val
+		case "source", "src":
+			source = val
+		case "target", "destination", "dst":
+			target = val
+		case "readonly", "ro":
+			readonly = val == "" || val == "true" || val == "1"
+		case "tmpfs-size":
+			tmpfsOption = append(tmpfsOption, "size="+val)
+		case "tmpfs-mode":
+			tmpfsOption = append(tmpfsOption, "mode="+val)
+		default:
+			return false
+		}
+	}
+	if target == "" {
+		return false
+	}
+	switch mountType {
+	case "bind", "volume":
+		if source == "" {
+			option.VolumesDefault = append(option.VolumesDefault, accessor.VolumeItem{
+				Dest: target,
+			})
+			return true
+		}
+		item := accessor.VolumeItem{
+			Host:       source,
+			Dest:       target,
+			Permission: "write",
+		}
+		if readonly {
+			item.Permission = "read"
+		}
+		option.Volumes = append(option.Volumes, item)
+	case "tmpfs":
+		option.Tmpfs = append(option.Tmpfs, accessor.EnvItem{
+			Name:  target,
+			Value: strings.Join(tmpfsOption, ","),
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+func (self DockerRun) healthcheck(option *accessor.SiteEnvOption) *accessor.HealthcheckItem {
+	if option.Healthcheck == nil {
+		option.Healthcheck = &accessor.HealthcheckItem{}
+	}
+	return option.Healthcheck
+}
+
+// Build 与 Parse 相反，将容器配置转换为 docker run 命令
+func (self DockerRun) Build(name string, option accessor.SiteEnvOption) string {
+	args := []string{"docker run -d"}
+	add := func(flag string, value string) {
+		args = append(args, flag+" "+self.quote(value))
+	}
+	if name != "" {
+		add("--name", name)
+	}
+	if option.Restart != "" && option.Restart != "no" {
+		add("--restart", option.Restart)
+	}
+	if option.AutoRemove {
+		args = append(args, "--rm")
+	}
+	if option.Privileged {
+		args = append(args, "--privileged")
+	}
+	if option.Init {
+		args = append(args, "--init")
+	}
+	if option.Hostname != "" {
+		add("--hostname", option.Hostname)
+	}
+	if option.UseHostNetwork {
+		add("--network", "host")
+	}
+	for i, item := range option.Network {
+		// docker run 只能指定一个网络，其它网络需要创建后再执行 docker network connect
+		if i > 0 || option.UseHostNetwork {
+			break
+		}
+		add("--network", item.Name)
+		for _, alias := range item.Alise {
+			add("--network-alias", alias)
+		}
+		if item.IpV4 != "" {
+			add("--ip", item.IpV4)
+		}
+		if item.IpV6 != "" {
+			add("--ip6", item.IpV6)
+		}
+	}
+	if option.PublishAllPorts {
+		args = append(args, "-P")
+	}
+	for _, item := range option.Ports {
+		port := item.Dest
+		if item.Protocol != "" && !strings.Contains(port, "/") {
+			port += "/" + item.Protocol
+		}
+		if item.Host != "" {
+			port = item.Host + ":" + port
+		}
+		if item.HostIp != "" {
+			hostIp := item.HostIp
+			if strings.Contains(hostIp, ":") {
+				hostIp = "[" + hostIp + "]"
+			}
+			port = hostIp + ":" + port
+		}
+		add("-p", port)
+	}
+	for _, item := range option.Volumes {
+		volume := item.Host + ":" + item.Dest
+		if item.Permission == "read" || item.Permission == "readonly" {
+			volume += ":ro"
+		}
+		add("-v", volume)
+	}
+	for _, item := range option.VolumesDefault {
+		add("-v", item.Dest)
+	}
+	for _, item := range option.Links {
+		if item.Volume {
+			add("--volumes-from", item.Name)
+		}
+		if item.Alise != "" && item.Alise != item.Name {
+			add("--link", item.Name+":"+item.Alise)
+		} else {
+			add("--link", item.Name)
+		}
+	}
+	for _, item := range option.Environment {
+		add("-e", item.Name+"="+item.Value)
+	}
+	for _, item := range option.Label {
+		add("-l", item.Name+"="+item.Value)
+	}
+	if option.Cpus > 0 {
+		add("--cpus", strconv.FormatFloat(float64(option.Cpus), 'f', -1, 32))
+	}
+	if option.Memory > 0 {
+		add("-m", fmt.Sprintf("%dm", option.Memory))
+	}
+	if option.ShmSize != "" {
+		add("--shm-size", option.ShmSize)
+	}
+	if option.WorkDir != "" {
+		add("-w", option.WorkDir)
+	}
+	if option.User != "" {
+		add("-u", option.User)
+	}
+	for _, item := range option.Dns {
+		add("--dns", item)
+	}
+	for _, item := range option.ExtraHosts {
+		add("--add-host", item.Name+":"+item.Value)
+	}
+	if option.Log.Driver != "" {
+		add("--log-driver", option.Log.Driver)
+	}
+	if option.Log.MaxSize != "" {
+		add("--log-opt", "max-size="+option.Log.MaxSize)
+	}
+	if option.Log.MaxFile != "" {
+		add("--log-opt", "max-file="+option.Log.MaxFile)
+	}
+	if option.Healthcheck != nil && option.Healthcheck.Test != "" {
+		if option.Healthcheck.ShellType == "NONE" {
+			args = append(args, "--no-healthcheck")
+		} else {
+			add("--health-cmd", option.Healthcheck.Test)
+			if option.Healthcheck.Interval > 0 {
+				add("--health-interval", fmt.Sprintf("%ds", option.Healthcheck.Interval))
+			}
+			if option.Healthcheck.Timeout > 0 {
+				add("--health-timeout", fmt.Sprintf("%ds", option.Healthcheck.Timeout))
+			}
+			if option.Healthcheck.StartPeriod > 0 {
+				add("--health-start-period", fmt.Sprintf("%ds", option.Healthcheck.StartPeriod))
+			}
+			if option.Healthcheck.Retries > 0 {
+				add("--health-retries", strconv.Itoa(option.Healthcheck.Retries))
+			}
+		}
+	}
+	for _, item := range option.CapAdd {
+		add("--cap-add", item)
+	}
+	for _, item := range option.CapDrop {
+		add("--cap-drop", item)
+	}
+	for _, item := range option.Devices {
+		device := item.Host
+		if item.Dest != "" {
+			device += ":" + item.Dest
+		}
+		if item.Permission != "" {
+			device += ":" + item.Permission
+		}
+		add("--device", device)
+	}
+	for _, item := range option.Ulimits {
+		if item.Soft == item.Hard {
+			add("--ulimit", fmt.Sprintf("%s=%d", item.Name, item.Soft))
+		} else {
+			add("--ulimit", fmt.Sprintf("%s=%d:%d", item.Name, item.Soft, item.Hard))
+		}
+	}
+	for _, item := range option.Sysctls {
+		add("--sysctl", item.Name+"="+item.Value)
+	}
+	for _, item := range option.SecurityOpt {
+		add("--security-opt", item)
+	}
+	for _, item := range option.Tmpfs {
+		if item.Value != "" {
+			add("--tmpfs", item.Name+":"+item.Value)
+		} else {
+			add("--tmpfs", item.Name)
+		}
+	}
+	if option.StopSignal != "" {
+		add("--stop-signal", option.StopSignal)
+	}
+	if option.StopTimeout > 0 {
+		add("--stop-timeout", strconv.Itoa(option.StopTimeout))
+	}
+
+	// --entrypoint 只能指定一个参数，其余的参数需要放到命令前面
+	command := function.CommandSplit(option.Command)
+	if option.Entrypoint != "" {
+		entrypoint := function.CommandSplit(option.Entrypoint)
+		if len(entrypoint) > 0 {
+			add("--entrypoint", entrypoint[0])
+			command = append(entrypoint[1:], command...)
+		}
+	}
+	image := []string{self.quote(option.ImageName)}
+	for _, item := range command {
+		image = append(image, self.quote(item))
+	}
+	args = append(args, strings.Join(image, " "))
+	return strings.Join(args, " \\\n  ")
+}
+
+// 参数中包含特殊字符时使用单引号包裹
+func (self DockerRun) quote(value string) string {
+	if value == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(value, " \t\n\"'\\$`!&|;<>()*?[]#~{}") {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -36,6 +37,8 @@ func NewExplorer(md5 string) (*explorer, error) {
 	return o, nil
 }
 
+var fileStatRegexp = regexp.MustCompile("`(\\d+)` `([^`]+)` ([^\\n]+)")
+
 type explorer struct {
 	pluginName string
 	rootPath   string
@@ -258,6 +261,39 @@ func (self explorer) GetPasswd() ([]*userItemResult, error) {
 	return result, nil
 }
 
+// GetFileStat 批量获取文件大小及类型，不存在的文件不返回
+func (self explorer) GetFileStat(fileList []string) (map[string]*fileStatResult, error) {
+	result := make(map[string]*fileStatResult)
+	// 分批执行，避免命令过长
+	for start := 0; start < len(fileList); start += 200 {
+		end := start + 200
+		if end > len(fileList) {
+			end = len(fileList)
+		}
+		pathList := make([]string, 0)
+		for _, path := range fileList[start:end] {
+			if !strings.HasPrefix(path, "/") {
+				return nil, errors.New("please use absolute address")
+			}
+			pathList = append(pathList, "'"+strings.ReplaceAll(self.rootPath+path, "'", `'\''`)+"'")
+		}
+		cmd := fmt.Sprintf("stat -c '`%%s` `%%F` %%n' %s 2>/dev/null \n", strings.Join(pathList, " "))
+		out, err := plugin.Command{}.Result(self.pluginName, cmd)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range fileStatRegexp.FindAllStringSubmatch(out, -1) {
+			size, _ := strconv.ParseInt(match[1], 10, 64)
+			path := strings.TrimPrefix(match[3], self.rootPath)
+			result[path] = &fileStatResult{
+				Size:  size,
+				IsDir: match[2] == "directory",
+			}
+		}
+	}
+	return result, nil
+}
+
 // Deprecated: 无用
 func (self explorer) Rename(file string, newFileName string) error {
 	if !strings.HasPrefix(file, "/") || strings.Contains(newFileName, "/") {
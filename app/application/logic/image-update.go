@@ -0,0 +1,201 @@
+package logic
+
+import (
+	"errors"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/service/docker"
+	"github.com/donknap/dpanel/common/service/notice"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	// ContainerAutoUpgradeLabel 容器添加该标签并设置为 true 时，检测到镜像更新后自动升级
+	ContainerAutoUpgradeLabel = "com.dpanel.container.auto-upgrade"
+	imageUpdateCheckInterval  = time.Hour * 6
+)
+
+type ImageUpdate struct {
+}
+
+// Check 检查运行中容器的镜像在仓库中是否有新的版本，相同的镜像只请求一次仓库
+func (self ImageUpdate) Check(md5 ...string) ([]*entity.ImageUpdate, error) {
+	filter := filters.NewArgs(filters.Arg("status", "running"))
+	for _, id := range md5 {
+		filter.Add("id", id)
+	}
+	list, err := docker.Sdk.Client.ContainerList(docker.Sdk.Ctx, container.ListOptions{
+		Filters: filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.ImageUpdate, 0)
+	checked := make(map[string]struct{})
+	for _, item := range list {
+		// 通过镜像 ID 创建的容器无法对应到仓库
+		if item.Image == "" || (DockerTask{}).isImageId(item.Image) {
+			continue
+		}
+		if _, ok := checked[item.Image]; ok {
+			continue
+		}
+		checked[item.Image] = struct{}{}
+		row := self.checkTag(item.Image, item.ImageID)
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+// GetListByTag 获取镜像的更新状态
+func (self ImageUpdate) GetListByTag(tag ...string) []*entity.ImageUpdate {
+	if len(tag) == 0 {
+		return make([]*entity.ImageUpdate, 0)
+	}
+	list, _ := dao.ImageUpdate.Where(dao.ImageUpdate.Tag.In(tag...)).Find()
+	return list
+}
+
+// MonitorLoop 定时检查镜像更新，并升级开启了自动升级的容器
+func (self ImageUpdate) MonitorLoop() {
+	// 启动后先检查一次，不需要等待第一个周期
+	self.checkAndUpgrade()
+	ticker := time.NewTicker(imageUpdateCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		self.checkAndUpgrade()
+	}
+}
+
+func (self ImageUpdate) checkAndUpgrade() {
+	updateList, err := self.Check()
+	if err != nil {
+		slog.Debug("image update", "check", err.Error())
+		return
+	}
+	self.AutoUpgrade(updateList)
+}
+
+// AutoUpgrade 升级开启了自动升级并且镜像有更新的容器
+func (self ImageUpdate) AutoUpgrade(updateList []*entity.ImageUpdate) {
+	upgradable := make(map[string]*entity.ImageUpdate)
+	for _, item := range updateList {
+		if item.Upgradable {
+			upgradable[item.Tag] = item
+		}
+	}
+	if len(upgradable) == 0 {
+		return
+	}
+	list, err := docker.Sdk.Client.ContainerList(docker.Sdk.Ctx, container.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("status", "running"),
+			filters.Arg("label", ContainerAutoUpgradeLabel+"=true"),
+		),
+	})
+	if err != nil {
+		slog.Debug("image update", "auto upgrade", err.Error())
+		return
+	}
+	for _, item := range list {
+		if _, ok := upgradable[item.Image]; !ok {
+			continue
+		}
+		name := strings.TrimPrefix(item.Names[0], "/")
+		result, err := DockerTask{}.ContainerUpgrade(&ContainerUpgradeOption{
+			Md5: item.ID,
+		})
+		if err != nil {
+			_ = notice.Message{}.Error("containerAutoUpgrade", name, err.Error())
+			continue
+		}
+		if result.Upgraded {
+			_ = notice.Message{}.Success("containerAutoUpgrade", name, result.ImageName)
+		}
+	}
+	// 升级后重新检查一次，更新镜像状态
+	tags := make([]string, 0)
+	for tag := range upgradable {
+		tags = append(tags, tag)
+	}
+	for _, row := range self.GetListByTag(tags...) {
+		imageInfo, _, err := docker.Sdk.Client.ImageInspectWithRaw(docker.Sdk.Ctx, row.Tag)
+		if err == nil && imageInfo.ID != row.ImageID {
+			self.checkTag(row.Tag, imageInfo.ID)
+		}
+	}
+}
+
+func (self ImageUpdate) checkTag(tag string, imageId string) *entity.ImageUpdate {
+	now := time.Now().Local()
+	row := &entity.ImageUpdate{
+		Tag:       tag,
+		ImageID:   imageId,
+		CheckedAt: now,
+	}
+	oldRow, _ := dao.ImageUpdate.Where(dao.ImageUpdate.Tag.Eq(tag)).First()
+
+	remoteDigest, localDigest, err := self.getDigest(tag, imageId)
+	row.LocalDigest = localDigest
+	row.RemoteDigest = remoteDigest
+	if err != nil {
+		row.Message = err.Error()
+	} else {
+		row.Upgradable = remoteDigest != "" && !strings.HasSuffix(localDigest, "@"+remoteDigest)
+	}
+	if row.Upgradable {
+		// 仓库中的镜像没有再变化时保留首次检测到的时间
+		if oldRow != nil && oldRow.Upgradable && oldRow.RemoteDigest == row.RemoteDigest {
+			row.DetectedAt = oldRow.DetectedAt
+		} else {
+			row.DetectedAt = now
+		}
+	}
+
+	if oldRow == nil {
+		_ = dao.ImageUpdate.Create(row)
+	} else {
+		row.ID = oldRow.ID
+		_, _ = dao.ImageUpdate.Where(dao.ImageUpdate.ID.Eq(oldRow.ID)).Select(dao.ImageUpdate.ALL).Updates(row)
+	}
+	return row
+}
+
+// 获取仓库中的镜像摘要，及本地镜像拉取时记录的摘要
+func (self ImageUpdate) getDigest(tag string, imageId string) (remoteDigest string, localDigest string, err error) {
+	imageInfo, _, err := docker.Sdk.Client.ImageInspectWithRaw(docker.Sdk.Ctx, imageId)
+	if err != nil {
+		return "", "", err
+	}
+	if len(imageInfo.RepoDigests) == 0 {
+		return "", "", errors.New("本地构建的镜像，无法检查更新")
+	}
+	authString, proxyList := Image{}.GetRegistryAuth(tag)
+	distribution, err := docker.Sdk.Client.DistributionInspect(docker.Sdk.Ctx, tag, authString)
+	if err != nil {
+		// 原始仓库无法访问时，尝试通过代理地址获取
+		for _, proxy := range proxyList {
+			distribution, err = docker.Sdk.Client.DistributionInspect(docker.Sdk.Ctx, Image{}.GetProxyImageTag(proxy, tag), authString)
+			if err == nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return "", imageInfo.RepoDigests[0], err
+	}
+	remoteDigest = distribution.Descriptor.Digest.String()
+
+	localDigest = imageInfo.RepoDigests[0]
+	for _, item := range imageInfo.RepoDigests {
+		if strings.HasSuffix(item, "@"+remoteDigest) {
+			localDigest = item
+			break
+		}
+	}
+	return remoteDigest, localDigest, nil
+}
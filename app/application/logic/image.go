@@ -1,6 +1,7 @@
 package logic
 
 import (
+	"github.com/donknap/dpanel/common/dao"
 	"github.com/donknap/dpanel/common/function"
 	"github.com/we7coreteam/w7-rangine-go/v2/pkg/support/facade"
 	"strings"
@@ -94,3 +95,31 @@ func (self Image) GetRegistryAuthString(serverAddress string, username string, p
 	})
 	return authString
 }
+
+// GetRegistryAuth 获取镜像所在仓库的授权信息及配置的代理地址
+func (self Image) GetRegistryAuth(tag string) (authString string, proxyList []string) {
+	tagDetail := self.GetImageTagDetail(tag)
+	proxyList = make([]string, 0)
+	// 从官方仓库拉取镜像不用权限
+	registry, _ := dao.Registry.Where(dao.Registry.ServerAddress.Eq(tagDetail.Registry)).Find()
+	for _, registryRow := range registry {
+		if registryRow.Setting.Username == tagDetail.Namespace {
+			authString = self.GetRegistryAuthString(registryRow.ServerAddress, registryRow.Setting.Username, registryRow.Setting.Password)
+		}
+		proxyList = append(proxyList, registryRow.Setting.Proxy...)
+	}
+	if authString == "" && registry != nil && len(registry) > 0 {
+		authString = self.GetRegistryAuthString(registry[0].ServerAddress, registry[0].Setting.Username, registry[0].Setting.Password)
+	}
+	return authString, proxyList
+}
+
+// GetProxyImageTag 获取镜像在代理地址中的名称
+func (self Image) GetProxyImageTag(proxy string, tag string) string {
+	tagDetail := self.GetImageTagDetail(tag)
+	proxyImageTag := strings.Trim(strings.TrimPrefix(proxy, "https://"), "/")
+	if tagDetail.Registry == "docker.io" && strings.Count(tagDetail.ImageName, "/") == 0 {
+		proxyImageTag += "/library"
+	}
+	return proxyImageTag + "/" + tagDetail.ImageName
+}
@@ -0,0 +1,168 @@
+package logic
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
+	"github.com/donknap/dpanel/common/service/docker"
+	"github.com/donknap/dpanel/common/service/storage"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	LogArchiveReasonDelete   = "delete"
+	LogArchiveReasonRecreate = "recreate"
+	LogArchiveReasonManual   = "manual"
+
+	logArchiveDefaultRetentionDays = 30
+	logArchiveDefaultMaxCount      = 10
+	logArchiveTimeout              = time.Minute * 5 // 读取日志的最长时间，避免删除容器时一直等待
+)
+
+type LogArchive struct {
+}
+
+func (self LogArchive) GetSetting() *accessor.LogArchiveSetting {
+	setting := &accessor.LogArchiveSetting{}
+	row, err := commonLogic.Setting{}.GetValue(commonLogic.SettingGroupSetting, commonLogic.SettingGroupSettingLogArchive)
+	if err == nil && row.Value != nil && row.Value.LogArchive != nil {
+		setting = row.Value.LogArchive
+	}
+	if setting.RetentionDays <= 0 {
+		setting.RetentionDays = logArchiveDefaultRetentionDays
+	}
+	if setting.MaxCount <= 0 {
+		setting.MaxCount = logArchiveDefaultMaxCount
+	}
+	return setting
+}
+
+// Archive 将容器的全部日志压缩保存到面板存储目录中
+// 在删除或是重建容器前调用，未开启归档或是容器没有日志时返回 nil
+// containerName 为空时使用容器当前的名称，重建时旧容器已经被重命名，需要传入原来的名称
+func (self LogArchive) Archive(md5 string, containerName string, reason string) (*entity.LogArchive, error) {
+	setting := self.GetSetting()
+	if setting.Disable && reason != LogArchiveReasonManual {
+		return nil, nil
+	}
+	containerInfo, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, md5)
+	if err != nil {
+		return nil, err
+	}
+	// 只有 json-file 等本地驱动支持读取日志
+	if containerInfo.HostConfig != nil && !function.InArray([]string{"", "json-file", "local", "journald"}, containerInfo.HostConfig.LogConfig.Type) {
+		return nil, nil
+	}
+	name := containerName
+	if name == "" {
+		name = strings.TrimPrefix(containerInfo.Name, "/")
+	}
+	fileName := filepath.Join(name, fmt.Sprintf("%s-%d.log.gz", name, time.Now().UnixNano()))
+	path := storage.Local{}.GetLogArchivePath(fileName)
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := int64(0)
+	gzipWriter := gzip.NewWriter(file)
+	writer := bufio.NewWriter(gzipWriter)
+
+	ctx, cancel := context.WithTimeout(docker.Sdk.Ctx, logArchiveTimeout)
+	defer cancel()
+	err = ContainerLog{}.Stream(ctx, &ContainerLogOption{
+		Md5:        containerInfo.ID,
+		Timestamps: true,
+	}, func(line *ContainerLogLine) error {
+		lines++
+		_, err := writer.WriteString(line.Time + " " + line.Stream + " " + line.Content + "\n")
+		return err
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := gzipWriter.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || lines == 0 {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	row := &entity.LogArchive{
+		ContainerName: name,
+		ContainerID:   containerInfo.ID,
+		ImageName:     containerInfo.Config.Image,
+		Reason:        reason,
+		FileName:      fileName,
+		Size:          stat.Size(),
+		Lines:         lines,
+		CreatedAt:     time.Now(),
+	}
+	if err = dao.LogArchive.Create(row); err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	self.Purge(setting, name)
+	return row, nil
+}
+
+// ArchiveQuietly 归档失败时只记录日志，不影响后续删除容器的操作
+func (self LogArchive) ArchiveQuietly(md5 string, containerName string, reason string) {
+	if _, err := self.Archive(md5, containerName, reason); err != nil {
+		slog.Debug("log archive", "container", md5, "error", err.Error())
+	}
+}
+
+// Purge 删除超出保留天数的归档，containerName 不为空时同时按数量清理该容器的归档
+func (self LogArchive) Purge(setting *accessor.LogArchiveSetting, containerName string) {
+	list, _ := dao.LogArchive.Where(
+		dao.LogArchive.CreatedAt.Lt(time.Now().AddDate(0, 0, -setting.RetentionDays)),
+	).Find()
+	if containerName != "" {
+		more, _ := dao.LogArchive.Where(
+			dao.LogArchive.ContainerName.Eq(containerName),
+		).Order(dao.LogArchive.ID.Desc()).Offset(setting.MaxCount).Find()
+		list = append(list, more...)
+	}
+	for _, item := range list {
+		_ = self.Delete(item)
+	}
+}
+
+func (self LogArchive) Delete(row *entity.LogArchive) error {
+	path := storage.Local{}.GetLogArchivePath(row.FileName)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	_, err := dao.LogArchive.Where(dao.LogArchive.ID.Eq(row.ID)).Delete()
+	return err
+}
+
+// GetRealPath 返回归档文件的绝对路径，防止文件名中包含 ../ 越出存储目录
+func (self LogArchive) GetRealPath(row *entity.LogArchive) (string, error) {
+	root := storage.Local{}.GetLogArchivePath("")
+	path := storage.Local{}.GetLogArchivePath(row.FileName)
+	if !strings.HasPrefix(path, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid archive file %s", row.FileName)
+	}
+	return path, nil
+}
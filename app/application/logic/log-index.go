@@ -0,0 +1,328 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	commonLogic "github.com/donknap/dpanel/app/common/logic"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/service/docker"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	logIndexFtsTable             = "ims_container_log_fts"
+	logIndexBatchSize            = 200
+	logIndexInitTail             = "1000" // 首次收集时读取的历史日志行数
+	logIndexDefaultRetentionDays = 7
+	logIndexDefaultMaxRows       = 1000000
+	logIndexMaxContext           = 10
+)
+
+var (
+	logIndexQueue  = make(chan *entity.ContainerLog, 5000)
+	logIndexLock   = sync.Mutex{}
+	logIndexStream = make(map[string]*logIndexTail) // 正在收集日志的容器
+	logIndexPurge  = time.Time{}
+)
+
+type logIndexTail struct {
+	cancel context.CancelFunc
+}
+
+type LogIndexSearchOption struct {
+	Query         string
+	Since         time.Time
+	Until         time.Time
+	ContainerName []string
+	Compose       string
+	Page          int
+	PageSize      int
+	Context       int // 返回匹配行前后的行数
+}
+
+type LogIndexSearchItem struct {
+	*entity.ContainerLog
+	Before []*entity.ContainerLog `json:"before"`
+	After  []*entity.ContainerLog `json:"after"`
+}
+
+type LogIndex struct {
+}
+
+func (self LogIndex) GetSetting() *accessor.LogIndexSetting {
+	setting := &accessor.LogIndexSetting{}
+	row, err := commonLogic.Setting{}.GetValue(commonLogic.SettingGroupSetting, commonLogic.SettingGroupSettingLogIndex)
+	if err == nil && row.Value != nil && row.Value.LogIndex != nil {
+		setting = row.Value.LogIndex
+	}
+	if setting.RetentionDays <= 0 {
+		setting.RetentionDays = logIndexDefaultRetentionDays
+	}
+	if setting.MaxRows <= 0 {
+		setting.MaxRows = logIndexDefaultMaxRows
+	}
+	return setting
+}
+
+// MonitorLoop 定时同步需要收集日志的容器，日志写入全文索引
+func (self LogIndex) MonitorLoop() {
+	if err := self.initTable(); err != nil {
+		slog.Debug("log index", "init table", err.Error())
+		return
+	}
+	go self.writeLoop()
+	ticker := time.NewTicker(time.Second * 10)
+	defer ticker.Stop()
+	for {
+		self.Refresh()
+		<-ticker.C
+	}
+}
+
+// Refresh 开始收集新启动的容器，停止收集已经不符合条件的容器
+func (self LogIndex) Refresh() {
+	setting := self.GetSetting()
+	running := make(map[string]types.Container)
+	if setting.Enable {
+		filtersArgs := filters.NewArgs()
+		filtersArgs.Add("status", "running")
+		if setting.Label != "" {
+			filtersArgs.Add("label", setting.Label)
+		}
+		containerList, err := docker.Sdk.Client.ContainerList(docker.Sdk.Ctx, container.ListOptions{
+			Filters: filtersArgs,
+		})
+		if err != nil {
+			slog.Debug("log index", "container list", err.Error())
+			return
+		}
+		for _, item := range containerList {
+			running[item.ID] = item
+		}
+	}
+
+	logIndexLock.Lock()
+	for id, stream := range logIndexStream {
+		if _, ok := running[id]; !ok {
+			stream.cancel()
+			delete(logIndexStream, id)
+		}
+	}
+	for id, item := range running {
+		if _, ok := logIndexStream[id]; !ok {
+			self.tail(item)
+		}
+	}
+	// 保存设置时也会调用 Refresh，在锁内判断清理时间，避免同时清理
+	needPurge := time.Since(logIndexPurge) > time.Hour
+	if needPurge {
+		logIndexPurge = time.Now()
+	}
+	logIndexLock.Unlock()
+
+	if needPurge {
+		self.purge(setting)
+	}
+}
+
+func (self LogIndex) Search(option *LogIndexSearchOption) ([]*LogIndexSearchItem, int64, error) {
+	query := dao.ContainerLog.UnderlyingDB().Model(&entity.ContainerLog{})
+	if option.Query != "" {
+		if self.isAscii(option.Query) {
+			// 作为短语查询，避免用户输入被当作 fts 语法
+			query = query.Where(
+				fmt.Sprintf("id IN (SELECT docid FROM %s WHERE %s MATCH ?)", logIndexFtsTable, logIndexFtsTable),
+				`"`+strings.ReplaceAll(option.Query, `"`, `""`)+`"`,
+			)
+		} else {
+			// 默认分词器无法处理中文，直接模糊匹配
+			query = query.Where("content LIKE ?", "%"+option.Query+"%")
+		}
+	}
+	if !option.Since.IsZero() {
+		query = query.Where("log_at >= ?", option.Since)
+	}
+	if !option.Until.IsZero() {
+		query = query.Where("log_at <= ?", option.Until)
+	}
+	if len(option.ContainerName) > 0 {
+		query = query.Where("container_name IN ?", option.ContainerName)
+	}
+	if option.Compose != "" {
+		query = query.Where("compose_project = ?", option.Compose)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	list := make([]*entity.ContainerLog, 0)
+	err := query.Order("log_at DESC, id DESC").
+		Offset((option.Page - 1) * option.PageSize).
+		Limit(option.PageSize).
+		Find(&list).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if option.Context > logIndexMaxContext {
+		option.Context = logIndexMaxContext
+	}
+	result := make([]*LogIndexSearchItem, 0, len(list))
+	for _, row := range list {
+		item := &LogIndexSearchItem{
+			ContainerLog: row,
+			Before:       make([]*entity.ContainerLog, 0),
+			After:        make([]*entity.ContainerLog, 0),
+		}
+		if option.Context > 0 {
+			before, _ := dao.ContainerLog.Where(
+				dao.ContainerLog.ContainerName.Eq(row.ContainerName),
+				dao.ContainerLog.ID.Lt(row.ID),
+			).Order(dao.ContainerLog.ID.Desc()).Limit(option.Context).Find()
+			for i := len(before) - 1; i >= 0; i-- {
+				item.Before = append(item.Before, before[i])
+			}
+			after, _ := dao.ContainerLog.Where(
+				dao.ContainerLog.ContainerName.Eq(row.ContainerName),
+				dao.ContainerLog.ID.Gt(row.ID),
+			).Order(dao.ContainerLog.ID).Limit(option.Context).Find()
+			item.After = append(item.After, after...)
+		}
+		result = append(result, item)
+	}
+	return result, total, nil
+}
+
+// 使用外部内容的 fts4 表，通过触发器与日志表保持同步
+func (self LogIndex) initTable() error {
+	db := dao.ContainerLog.UnderlyingDB()
+	sqlList := []string{
+		fmt.Sprintf(`CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts4(content, content="%s", tokenize=unicode61)`,
+			logIndexFtsTable, entity.TableNameContainerLog),
+		fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %s_ai AFTER INSERT ON %s BEGIN INSERT INTO %s(docid, content) VALUES (new.id, new.content); END`,
+			entity.TableNameContainerLog, entity.TableNameContainerLog, logIndexFtsTable),
+		fmt.Sprintf(`CREATE TRIGGER IF NOT EXISTS %s_bd BEFORE DELETE ON %s BEGIN DELETE FROM %s WHERE docid = old.id; END`,
+			entity.TableNameContainerLog, entity.TableNameContainerLog, logIndexFtsTable),
+	}
+	for _, sql := range sqlList {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 调用时需要持有 logIndexLock
+func (self LogIndex) tail(item types.Container) {
+	name := strings.TrimPrefix(item.Names[0], "/")
+	option := &ContainerLogOption{
+		Md5:        item.ID,
+		Follow:     true,
+		Timestamps: true,
+	}
+	// 从上次收集的位置继续，首次只收集最近的部分日志
+	lastRow, _ := dao.ContainerLog.Where(dao.ContainerLog.ContainerName.Eq(name)).
+		Order(dao.ContainerLog.LogAt.Desc()).Take()
+	if lastRow != nil {
+		since := lastRow.LogAt.Add(time.Nanosecond)
+		option.Since = fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond())
+	} else {
+		option.Tail = logIndexInitTail
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &logIndexTail{
+		cancel: cancel,
+	}
+	logIndexStream[item.ID] = stream
+	go func() {
+		err := ContainerLog{}.Stream(ctx, option, func(line *ContainerLogLine) error {
+			logAt, err := time.Parse(time.RFC3339Nano, line.Time)
+			if err != nil {
+				logAt = time.Now()
+			}
+			row := &entity.ContainerLog{
+				ContainerName:  name,
+				ComposeProject: item.Labels["com.docker.compose.project"],
+				Stream:         line.Stream,
+				Content:        line.Content,
+				LogAt:          logAt,
+			}
+			select {
+			case logIndexQueue <- row:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			slog.Debug("log index", "tail", name, "error", err.Error())
+		}
+		// 容器停止后日志流结束，下次同步时重新开始收集
+		logIndexLock.Lock()
+		cancel()
+		if logIndexStream[item.ID] == stream {
+			delete(logIndexStream, item.ID)
+		}
+		logIndexLock.Unlock()
+	}()
+}
+
+// 批量写入日志，减少数据库写入次数
+func (self LogIndex) writeLoop() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	rows := make([]*entity.ContainerLog, 0, logIndexBatchSize)
+	flush := func() {
+		if len(rows) == 0 {
+			return
+		}
+		if err := dao.ContainerLog.CreateInBatches(rows, logIndexBatchSize); err != nil {
+			slog.Debug("log index", "write", err.Error())
+		}
+		rows = make([]*entity.ContainerLog, 0, logIndexBatchSize)
+	}
+	for {
+		select {
+		case row := <-logIndexQueue:
+			rows = append(rows, row)
+			if len(rows) >= logIndexBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (self LogIndex) purge(setting *accessor.LogIndexSetting) {
+	_, err := dao.ContainerLog.Where(
+		dao.ContainerLog.LogAt.Lt(time.Now().AddDate(0, 0, -setting.RetentionDays)),
+	).Delete()
+	if err != nil {
+		slog.Debug("log index", "purge", err.Error())
+	}
+	// 超出行数限制时删除最早的日志
+	row, _ := dao.ContainerLog.Order(dao.ContainerLog.ID.Desc()).Offset(setting.MaxRows).Take()
+	if row != nil {
+		_, _ = dao.ContainerLog.Where(dao.ContainerLog.ID.Lte(row.ID)).Delete()
+	}
+}
+
+func (self LogIndex) isAscii(str string) bool {
+	for _, c := range str {
+		if c > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,208 @@
+package logic
+
+import (
+	"errors"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/service/docker"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	rightsizingMinSample = 20   // 少于这个采样数不给出建议
+	rightsizingHeadroom  = 1.25 // 建议值在观测值基础上预留的余量
+	rightsizingCpuStep   = 0.05
+	rightsizingMemoryMin = 32 // MB
+	rightsizingMemoryMul = 16 // MB
+)
+
+type RightsizingUsage struct {
+	P95   float64 `json:"p95"`
+	Max   float64 `json:"max"`
+	Limit float64 `json:"limit"` // 为 0 时表示未限制
+}
+
+type RightsizingSuggestion struct {
+	ContainerId   string                  `json:"containerId"`
+	ContainerName string                  `json:"containerName"`
+	SampleTotal   int                     `json:"sampleTotal"`
+	Cpu           RightsizingUsage        `json:"cpu"`    // 核心数
+	Memory        RightsizingUsage        `json:"memory"` // MB
+	NoLimit       bool                    `json:"noLimit"`
+	Reason        []string                `json:"reason"`
+	Suggest       *accessor.SiteEnvOption `json:"suggest"` // 只包含 Cpus Memory
+}
+
+type Rightsizing struct {
+}
+
+// GetSuggestion 根据最近的资源采样计算容器的限制建议，md5 为空时获取所有运行中的容器
+func (self Rightsizing) GetSuggestion(md5 string, since time.Time) ([]*RightsizingSuggestion, error) {
+	var idList []string
+	if md5 != "" {
+		idList = append(idList, md5)
+	} else {
+		list, err := docker.Sdk.Client.ContainerList(docker.Sdk.Ctx, container.ListOptions{
+			Filters: filters.NewArgs(filters.Arg("status", "running")),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list {
+			idList = append(idList, item.ID)
+		}
+	}
+
+	result := make([]*RightsizingSuggestion, 0)
+	for _, id := range idList {
+		info, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(info.Name, "/")
+		statList, _ := dao.ContainerStat.Where(
+			dao.ContainerStat.ContainerName.Eq(name),
+			dao.ContainerStat.CreatedAt.Gte(since),
+		).Find()
+
+		item := &RightsizingSuggestion{
+			ContainerId:   info.ID,
+			ContainerName: name,
+			SampleTotal:   len(statList),
+			Reason:        make([]string, 0),
+		}
+		if info.HostConfig.NanoCPUs > 0 {
+			item.Cpu.Limit = float64(info.HostConfig.NanoCPUs) / 1e9
+		} else if info.HostConfig.CPUQuota > 0 {
+			period := info.HostConfig.CPUPeriod
+			if period <= 0 {
+				period = 100000
+			}
+			item.Cpu.Limit = float64(info.HostConfig.CPUQuota) / float64(period)
+		}
+		if info.HostConfig.Memory > 0 {
+			item.Memory.Limit = float64(info.HostConfig.Memory) / 1024 / 1024
+		}
+		if item.Cpu.Limit == 0 {
+			item.Reason = append(item.Reason, "未设置 CPU 限制")
+		}
+		if item.Memory.Limit == 0 {
+			item.Reason = append(item.Reason, "未设置内存限制")
+		}
+		item.NoLimit = item.Cpu.Limit == 0 && item.Memory.Limit == 0
+
+		if item.SampleTotal < rightsizingMinSample {
+			item.Reason = append(item.Reason, "采样数据不足，暂时无法给出建议")
+			result = append(result, item)
+			continue
+		}
+
+		cpuList := make([]float64, 0, len(statList))
+		memoryList := make([]float64, 0, len(statList))
+		for _, stat := range statList {
+			// 采样的 cpu 为 docker stats 的百分比，100% 为一个核心
+			cpuList = append(cpuList, stat.Cpu/100)
+			memoryList = append(memoryList, float64(stat.Memory)/1024/1024)
+		}
+		item.Cpu.P95, item.Cpu.Max = self.percentile(cpuList, 0.95), self.percentile(cpuList, 1)
+		item.Memory.P95, item.Memory.Max = self.percentile(memoryList, 0.95), self.percentile(memoryList, 1)
+
+		// cpu 超出限制只会被限流，按 p95 计算；内存超出会被 OOM，按最大值计算
+		item.Suggest = &accessor.SiteEnvOption{
+			Cpus:   float32(math.Max(rightsizingCpuStep*2, math.Ceil(item.Cpu.P95*rightsizingHeadroom/rightsizingCpuStep)*rightsizingCpuStep)),
+			Memory: int(math.Max(rightsizingMemoryMin, math.Ceil(item.Memory.Max*rightsizingHeadroom/rightsizingMemoryMul)*rightsizingMemoryMul)),
+		}
+		if item.Cpu.Limit > 0 {
+			if item.Cpu.Max >= item.Cpu.Limit*0.9 {
+				item.Reason = append(item.Reason, "CPU 使用已接近限制")
+			} else if item.Cpu.P95 < item.Cpu.Limit*0.3 {
+				item.Reason = append(item.Reason, "CPU 限制远高于实际使用")
+			}
+		}
+		if item.Memory.Limit > 0 {
+			if item.Memory.Max >= item.Memory.Limit*0.9 {
+				item.Reason = append(item.Reason, "内存使用已接近限制")
+			} else if item.Memory.Max < item.Memory.Limit*0.3 {
+				item.Reason = append(item.Reason, "内存限制远高于实际使用")
+			}
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// Apply 直接更新运行中容器的资源限制，无需重建容器
+func (self Rightsizing) Apply(md5 string, cpus float32, memory int, username string) error {
+	if cpus <= 0 && memory <= 0 {
+		return errors.New("请指定 CPU 或内存限制")
+	}
+	info, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, md5)
+	if err != nil {
+		return err
+	}
+	resources := container.Resources{}
+	if cpus > 0 {
+		// 通过 --cpu-quota 创建的容器不能同时设置 NanoCPUs
+		if info.HostConfig.CPUQuota > 0 && info.HostConfig.NanoCPUs == 0 {
+			period := info.HostConfig.CPUPeriod
+			if period <= 0 {
+				period = 100000
+			}
+			resources.CPUPeriod = period
+			resources.CPUQuota = int64(float64(cpus) * float64(period))
+		} else {
+			resources.NanoCPUs = int64(float64(cpus) * 1e9)
+		}
+	}
+	if memory > 0 {
+		resources.Memory = int64(memory) * 1024 * 1024
+		// 内存限制不能大于已经设置的 swap 限制，按原来 swap 与内存的比例调整，-1 不限制时保持不变
+		if info.HostConfig.MemorySwap > 0 && info.HostConfig.Memory > 0 {
+			ratio := float64(info.HostConfig.MemorySwap) / float64(info.HostConfig.Memory)
+			resources.MemorySwap = max(int64(float64(resources.Memory)*ratio), resources.Memory)
+		}
+	}
+	_, err = docker.Sdk.Client.ContainerUpdate(docker.Sdk.Ctx, info.ID, container.UpdateConfig{
+		Resources: resources,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 同步到站点配置中，重建时保持新的限制
+	siteRow, _ := dao.Site.Where(dao.Site.ContainerInfo.Eq(&accessor.SiteContainerInfoOption{
+		ID: info.ID,
+	})).First()
+	if siteRow != nil && siteRow.Env != nil {
+		if cpus > 0 {
+			siteRow.Env.Cpus = cpus
+		}
+		if memory > 0 {
+			siteRow.Env.Memory = memory
+		}
+		_, _ = dao.Site.Where(dao.Site.ID.Eq(siteRow.ID)).Updates(&entity.Site{
+			Env: siteRow.Env,
+		})
+		_ = SiteRevision{}.Add(siteRow.SiteName, siteRow.Env, username, "调整资源限制")
+	}
+	return nil
+}
+
+func (self Rightsizing) percentile(list []float64, p float64) float64 {
+	if len(list) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, list...)
+	sort.Float64s(sorted)
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
@@ -0,0 +1,94 @@
+package logic
+
+import (
+	"fmt"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/service/compose"
+	"github.com/donknap/dpanel/common/service/docker"
+	"regexp"
+	"strings"
+)
+
+// docker 自动生成的匿名存储卷名称
+var anonymousVolumeRegexp = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+type SiteExportConfigResult struct {
+	Name      string                 `json:"name"`
+	DockerRun string                 `json:"dockerRun"`
+	Compose   string                 `json:"compose"`
+	Option    accessor.SiteEnvOption `json:"option"`
+}
+
+// ExportConfig 将容器配置导出为 docker run 命令及 compose 服务
+func (self Site) ExportConfig(md5 string) (*SiteExportConfigResult, error) {
+	info, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, md5)
+	if err != nil {
+		return nil, err
+	}
+	option, err := self.GetEnvOptionByContainer(md5)
+	if err != nil {
+		return nil, err
+	}
+	option.Name = strings.TrimPrefix(info.Name, "/")
+	// 镜像中自带的配置不需要导出
+	DockerTask{}.removeImageDefault(&option, info.Image)
+	option.Label = self.StripInheritLabel(option.Label, info.Image)
+
+	volumes := make([]accessor.VolumeItem, 0)
+	for _, item := range option.Volumes {
+		if anonymousVolumeRegexp.MatchString(item.Host) {
+			option.VolumesDefault = append(option.VolumesDefault, accessor.VolumeItem{
+				Dest: item.Dest,
+			})
+		} else {
+			volumes = append(volumes, item)
+		}
+	}
+	option.Volumes = volumes
+	if option.Restart == "no" {
+		option.Restart = ""
+	}
+	if info.HostConfig.ShmSize == 0 || info.HostConfig.ShmSize == 64*1024*1024 {
+		option.ShmSize = ""
+	}
+	if option.Log.Driver == "json-file" && option.Log.MaxSize == "" && option.Log.MaxFile == "" {
+		option.Log.Driver = ""
+	}
+
+	dockerRun := DockerRun{}.Build(option.Name, option)
+	// docker run 只能加入一个网络，其它网络需要单独加入
+	if !option.UseHostNetwork && len(option.Network) > 1 {
+		commands := []string{dockerRun}
+		for _, item := range option.Network[1:] {
+			command := []string{"docker network connect"}
+			for _, alias := range item.Alise {
+				command = append(command, "--alias", DockerRun{}.quote(alias))
+			}
+			if item.IpV4 != "" {
+				command = append(command, "--ip", item.IpV4)
+			}
+			if item.IpV6 != "" {
+				command = append(command, "--ip6", item.IpV6)
+			}
+			command = append(command, DockerRun{}.quote(item.Name), DockerRun{}.quote(option.Name))
+			commands = append(commands, strings.Join(command, " "))
+		}
+		dockerRun = strings.Join(commands, "\n")
+	}
+
+	composer, err := compose.NewComposeBySiteEnv(option)
+	if err != nil {
+		return nil, err
+	}
+	composeYaml, err := composer.Project.MarshalYAML()
+	if err != nil {
+		return nil, fmt.Errorf("生成 compose 配置失败 %s", err.Error())
+	}
+
+	return &SiteExportConfigResult{
+		Name:      option.Name,
+		DockerRun: dockerRun,
+		Compose:   string(composeYaml),
+		Option:    option,
+	}, nil
+}
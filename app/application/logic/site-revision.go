@@ -0,0 +1,156 @@
+package logic
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/docker/docker/api/types/container"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
+	"github.com/donknap/dpanel/common/service/docker"
+	"reflect"
+	"sort"
+	"time"
+)
+
+type SiteRevisionDiffItem struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+type SiteRevision struct {
+}
+
+// Add 记录一次容器配置
+func (self SiteRevision) Add(siteName string, env *accessor.SiteEnvOption, username string, remark string) error {
+	if env == nil {
+		return nil
+	}
+	return dao.SiteRevision.Create(&entity.SiteRevision{
+		SiteName:  siteName,
+		Env:       env,
+		ImageID:   env.ImageId,
+		Username:  username,
+		Remark:    remark,
+		CreatedAt: time.Now().Local(),
+	})
+}
+
+// GetPrevious 获取某个版本的上一个版本
+func (self SiteRevision) GetPrevious(row *entity.SiteRevision) (*entity.SiteRevision, error) {
+	return dao.SiteRevision.Where(
+		dao.SiteRevision.SiteName.Eq(row.SiteName),
+		dao.SiteRevision.ID.Lt(row.ID),
+	).Order(dao.SiteRevision.ID.Desc()).First()
+}
+
+// Diff 按字段对比两个版本的配置
+func (self SiteRevision) Diff(old *accessor.SiteEnvOption, new *accessor.SiteEnvOption) ([]*SiteRevisionDiffItem, error) {
+	oldValue, err := self.toMap(old)
+	if err != nil {
+		return nil, err
+	}
+	newValue, err := self.toMap(new)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]struct{})
+	for key := range oldValue {
+		fields[key] = struct{}{}
+	}
+	for key := range newValue {
+		fields[key] = struct{}{}
+	}
+	result := make([]*SiteRevisionDiffItem, 0)
+	for field := range fields {
+		if reflect.DeepEqual(oldValue[field], newValue[field]) {
+			continue
+		}
+		result = append(result, &SiteRevisionDiffItem{
+			Field: field,
+			Old:   oldValue[field],
+			New:   newValue[field],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Field < result[j].Field
+	})
+	return result, nil
+}
+
+// Rollback 使用某个版本的配置重建容器
+func (self SiteRevision) Rollback(id int32, username string) (*ContainerRecreateResult, error) {
+	revisionRow, _ := dao.SiteRevision.Where(dao.SiteRevision.ID.Eq(id)).First()
+	if revisionRow == nil || revisionRow.Env == nil {
+		return nil, errors.New("配置版本不存在")
+	}
+	envOption := *revisionRow.Env
+	// 创建容器使用的配置，站点及版本记录中仍然保存原来的镜像标签
+	buildOption := envOption
+
+	// 镜像标签已经指向了新的镜像时，直接使用该版本的镜像 id 创建容器
+	// 不移动用户的标签，避免影响使用同一镜像的其它容器及镜像更新检测
+	if revisionRow.ImageID != "" && envOption.ImageName != "" {
+		imageInfo, _, err := docker.Sdk.Client.ImageInspectWithRaw(docker.Sdk.Ctx, envOption.ImageName)
+		if err != nil || imageInfo.ID != revisionRow.ImageID {
+			_, _, err = docker.Sdk.Client.ImageInspectWithRaw(docker.Sdk.Ctx, revisionRow.ImageID)
+			if err != nil {
+				return nil, errors.New("该版本使用的镜像已经被删除，无法回滚")
+			}
+			buildOption.ImageName = revisionRow.ImageID
+		}
+	}
+
+	var result *ContainerRecreateResult
+	oldInfo, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, revisionRow.SiteName)
+	if err == nil {
+		result, err = DockerTask{}.ContainerRecreate(oldInfo, &buildOption, false)
+		if err != nil {
+			return result, err
+		}
+		_, _ = dao.Site.Where(dao.Site.SiteName.Eq(revisionRow.SiteName)).Updates(&entity.Site{
+			Env: &envOption,
+		})
+	} else {
+		// 容器已经不存在时直接创建
+		containerId, err := DockerTask{}.ContainerCreate(&CreateContainerOption{
+			SiteName:    revisionRow.SiteName,
+			BuildParams: &buildOption,
+		})
+		if err != nil {
+			if containerId != "" {
+				_ = docker.Sdk.Client.ContainerRemove(docker.Sdk.Ctx, containerId, container.RemoveOptions{})
+			}
+			return nil, err
+		}
+		result = &ContainerRecreateResult{
+			ContainerId: containerId,
+		}
+		_, _ = dao.Site.Where(dao.Site.SiteName.Eq(revisionRow.SiteName)).Updates(&entity.Site{
+			Env: &envOption,
+			ContainerInfo: &accessor.SiteContainerInfoOption{
+				ID: containerId,
+			},
+		})
+	}
+	_ = self.Add(revisionRow.SiteName, &envOption, username, "回滚到版本 "+revisionRow.CreatedAt.Format(function.ShowYmdHis))
+	return result, nil
+}
+
+func (self SiteRevision) toMap(env *accessor.SiteEnvOption) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	if env == nil {
+		return result, nil
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -3,6 +3,8 @@ package logic
 import (
 	"embed"
 	"errors"
+	"fmt"
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/go-units"
 	"github.com/donknap/dpanel/common/accessor"
 	"github.com/donknap/dpanel/common/function"
@@ -39,10 +41,10 @@ func (self Site) GetEnvOptionByContainer(md5 string) (envOption accessor.SiteEnv
 
 	if !function.IsEmptyArray(info.Config.Env) {
 		for _, item := range info.Config.Env {
-			if envs := strings.Split(item, "="); len(envs) > 0 {
+			if name, value, ok := strings.Cut(item, "="); ok {
 				envOption.Environment = append(envOption.Environment, accessor.EnvItem{
-					Name:  envs[0],
-					Value: envs[1],
+					Name:  name,
+					Value: value,
 				})
 			}
 		}
@@ -114,13 +116,13 @@ func (self Site) GetEnvOptionByContainer(md5 string) (envOption accessor.SiteEnv
 	envOption.Privileged = info.HostConfig.Privileged
 	envOption.AutoRemove = info.HostConfig.AutoRemove
 	envOption.Restart = string(info.HostConfig.RestartPolicy.Name)
-	envOption.Cpus = float32(info.HostConfig.NanoCPUs / 1000000000)
+	envOption.Cpus = float32(info.HostConfig.NanoCPUs) / 1000000000
 	envOption.Memory = int(info.HostConfig.Memory / 1024 / 1024)
 	envOption.ShmSize = units.BytesSize(float64(info.HostConfig.ShmSize))
 	envOption.WorkDir = info.Config.WorkingDir
 	envOption.User = info.Config.User
-	envOption.Command = strings.Join(info.Config.Cmd, " ")
-	envOption.Entrypoint = strings.Join(info.Config.Entrypoint, " ")
+	envOption.Command = function.CommandJoin(info.Config.Cmd)
+	envOption.Entrypoint = function.CommandJoin(info.Config.Entrypoint)
 	envOption.UseHostNetwork = info.HostConfig.NetworkMode.IsHost()
 	envOption.Log = accessor.LogDriverItem{
 		Driver:  info.HostConfig.LogConfig.Type,
@@ -128,6 +130,12 @@ func (self Site) GetEnvOptionByContainer(md5 string) (envOption accessor.SiteEnv
 		MaxSize: info.HostConfig.LogConfig.Config["max-size"],
 	}
 	envOption.Dns = info.HostConfig.DNS
+	for name, value := range info.Config.Labels {
+		envOption.Label = append(envOption.Label, accessor.EnvItem{
+			Name:  name,
+			Value: value,
+		})
+	}
 	envOption.PublishAllPorts = info.HostConfig.PublishAllPorts
 	envOption.ExtraHosts = make([]accessor.EnvItem, 0)
 	for _, host := range info.HostConfig.ExtraHosts {
@@ -137,9 +145,95 @@ func (self Site) GetEnvOptionByContainer(md5 string) (envOption accessor.SiteEnv
 			Value: value[1],
 		})
 	}
+
+	envOption.Healthcheck = self.GetHealthcheckItem(info.Config.Healthcheck)
+	envOption.CapAdd = info.HostConfig.CapAdd
+	envOption.CapDrop = info.HostConfig.CapDrop
+	for _, item := range info.HostConfig.Devices {
+		envOption.Devices = append(envOption.Devices, accessor.DeviceItem{
+			Host:       item.PathOnHost,
+			Dest:       item.PathInContainer,
+			Permission: item.CgroupPermissions,
+		})
+	}
+	for _, item := range info.HostConfig.Ulimits {
+		envOption.Ulimits = append(envOption.Ulimits, accessor.UlimitItem{
+			Name: item.Name,
+			Soft: item.Soft,
+			Hard: item.Hard,
+		})
+	}
+	for name, value := range info.HostConfig.Sysctls {
+		envOption.Sysctls = append(envOption.Sysctls, accessor.EnvItem{
+			Name:  name,
+			Value: value,
+		})
+	}
+	envOption.SecurityOpt = info.HostConfig.SecurityOpt
+	for path, option := range info.HostConfig.Tmpfs {
+		envOption.Tmpfs = append(envOption.Tmpfs, accessor.EnvItem{
+			Name:  path,
+			Value: option,
+		})
+	}
+	envOption.Init = info.HostConfig.Init != nil && *info.HostConfig.Init
+	envOption.StopSignal = info.Config.StopSignal
+	if info.Config.StopTimeout != nil {
+		envOption.StopTimeout = *info.Config.StopTimeout
+	}
+	// 面板创建的默认主机名及 docker 随机生成的主机名不需要保留，
+	// 使用宿主机网络时主机名与宿主机一致，也不需要保留
+	if !info.HostConfig.NetworkMode.IsHost() &&
+		info.Config.Hostname != fmt.Sprintf(docker.HostnameTemplate, strings.TrimPrefix(info.Name, "/")) &&
+		!strings.HasPrefix(info.ID, info.Config.Hostname) {
+		envOption.Hostname = info.Config.Hostname
+	}
 	return envOption, nil
 }
 
+// StripInheritLabel 去掉 compose 的标签及从镜像中继承的标签
+// 复制或导出容器时 compose 的标签会让新容器被识别为 compose 中的服务，镜像中的标签创建时会自动继承
+func (self Site) StripInheritLabel(label []accessor.EnvItem, imageId string) []accessor.EnvItem {
+	imageLabels := make(map[string]string)
+	if imageInfo, _, err := docker.Sdk.Client.ImageInspectWithRaw(docker.Sdk.Ctx, imageId); err == nil && imageInfo.Config != nil {
+		imageLabels = imageInfo.Config.Labels
+	}
+	result := make([]accessor.EnvItem, 0, len(label))
+	for _, item := range label {
+		if strings.HasPrefix(item.Name, "com.docker.compose.") {
+			continue
+		}
+		if value, ok := imageLabels[item.Name]; ok && value == item.Value {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// GetHealthcheckItem 将容器或镜像中的健康检查转换为表单配置
+func (self Site) GetHealthcheckItem(config *container.HealthConfig) *accessor.HealthcheckItem {
+	if config == nil || len(config.Test) == 0 {
+		return nil
+	}
+	healthcheck := &accessor.HealthcheckItem{
+		ShellType:   config.Test[0],
+		Interval:    int(config.Interval.Seconds()),
+		Timeout:     int(config.Timeout.Seconds()),
+		StartPeriod: int(config.StartPeriod.Seconds()),
+		Retries:     config.Retries,
+	}
+	switch healthcheck.ShellType {
+	case "CMD":
+		healthcheck.Test = function.CommandJoin(config.Test[1:])
+	case "CMD-SHELL":
+		healthcheck.Test = strings.Join(config.Test[1:], "")
+	case "NONE":
+		healthcheck.Test = "NONE"
+	}
+	return healthcheck
+}
+
 func (self Site) MakeNginxConf(setting *accessor.SiteDomainSettingOption) error {
 	var asset embed.FS
 	err := facade.GetContainer().NamedResolve(&asset, "asset")
@@ -29,6 +29,11 @@ type fileItemResult struct {
 	Owner    string `json:"owner"`
 }
 
+type fileStatResult struct {
+	Size  int64 `json:"size"`
+	IsDir bool  `json:"isDir"`
+}
+
 type userItemResult struct {
 	Username    string `json:"username"`
 	UID         string `json:"uid"`
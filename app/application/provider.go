@@ -2,9 +2,11 @@ package application
 
 import (
 	"github.com/donknap/dpanel/app/application/http/controller"
+	"github.com/donknap/dpanel/app/application/logic"
 	common "github.com/donknap/dpanel/common/middleware"
 	"github.com/gin-gonic/gin"
 	http_server "github.com/we7coreteam/w7-rangine-go/v2/src/http/server"
+	"time"
 )
 
 type Provider struct {
@@ -22,6 +24,10 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 			cors.POST("/app/site/get-detail", controller.Site{}.GetDetail)
 			cors.POST("/app/site/delete", controller.Site{}.Delete)
 			cors.POST("/app/site/update-title", controller.Site{}.UpdateTitle)
+			cors.POST("/app/site/get-revision-list", controller.Site{}.GetRevisionList)
+			cors.POST("/app/site/diff-revision", controller.Site{}.DiffRevision)
+			cors.POST("/app/site/rollback-revision", controller.Site{}.RollbackRevision)
+			cors.POST("/app/site/parse-docker-run", controller.Site{}.ParseDockerRun)
 
 			cors.POST("/app/site/create-domain", controller.SiteDomain{}.Create)
 			cors.POST("/app/site/update-domain", controller.SiteDomain{}.UpdateDomain)
@@ -32,6 +38,8 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 
 			cors.POST("/app/site/restart-nginx", controller.SiteDomain{}.RestartNginx)
 
+			cors.POST("/app/home/apply-clean-recommend", controller.Home{}.ApplyCleanRecommend)
+
 			// 容器相关
 			cors.POST("/app/container/status", controller.Container{}.Status)
 			cors.POST("/app/container/get-list", controller.Container{}.GetList)
@@ -39,10 +47,24 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 			cors.POST("/app/container/update", controller.Container{}.Update)
 			cors.POST("/app/container/prune", controller.Container{}.Prune)
 			cors.POST("/app/container/delete", controller.Container{}.Delete)
+			cors.POST("/app/container/get-delete-impact", controller.Container{}.GetDeleteImpact)
 			cors.POST("/app/container/export", controller.Container{}.Export)
+			cors.POST("/app/container/export-config", controller.Container{}.ExportConfig)
+			cors.POST("/app/container/clone", controller.Container{}.Clone)
+			cors.POST("/app/container/commit", controller.Container{}.Commit)
+			cors.POST("/app/container/batch", controller.Container{}.Batch)
+			cors.POST("/app/container/get-batch-list", controller.Container{}.GetBatchList)
+			cors.POST("/app/container/exec", controller.Container{}.Exec)
+			cors.POST("/app/container/get-exec-list", controller.Container{}.GetExecList)
+			cors.POST("/app/container/delete-exec", controller.Container{}.DeleteExec)
+			cors.POST("/app/container/get-graph", controller.Container{}.GetGraph)
+			cors.POST("/app/container/upgrade", controller.Container{}.Upgrade)
+			cors.POST("/app/container/check-upgrade", controller.Container{}.CheckUpgrade)
 
 			cors.POST("/app/container/get-stat-info", controller.Container{}.GetStatInfo)
 			cors.POST("/app/container/get-process-info", controller.Container{}.GetProcessInfo)
+			cors.POST("/app/container/get-rightsizing-list", controller.Container{}.GetRightsizingList)
+			cors.POST("/app/container/apply-rightsizing", controller.Container{}.ApplyRightsizing)
 
 			// 镜像相关
 			cors.POST("/app/image/create-by-dockerfile", controller.Image{}.CreateByDockerfile)
@@ -78,9 +100,21 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 			cors.POST("/app/explorer/get-content", controller.Explorer{}.GetContent)
 			cors.POST("/app/explorer/chmod", controller.Explorer{}.Chmod)
 			cors.POST("/app/explorer/get-passwd", controller.Explorer{}.GetPasswd)
+			cors.POST("/app/explorer/get-diff", controller.Explorer{}.GetDiff)
+			cors.POST("/app/explorer/export-diff", controller.Explorer{}.ExportDiff)
 
 			// 日志相关
 			cors.POST("/app/log/run", controller.RunLog{}.Run)
+			cors.POST("/app/log/download", controller.RunLog{}.Download)
+			cors.POST("/app/log/search", controller.RunLog{}.Search)
+			cors.POST("/app/log/get-index-setting", controller.RunLog{}.GetIndexSetting)
+			cors.POST("/app/log/save-index-setting", controller.RunLog{}.SaveIndexSetting)
+			cors.POST("/app/log/archive", controller.RunLog{}.Archive)
+			cors.POST("/app/log/get-archive-list", controller.RunLog{}.GetArchiveList)
+			cors.POST("/app/log/download-archive", controller.RunLog{}.DownloadArchive)
+			cors.POST("/app/log/delete-archive", controller.RunLog{}.DeleteArchive)
+			cors.POST("/app/log/get-archive-setting", controller.RunLog{}.GetArchiveSetting)
+			cors.POST("/app/log/save-archive-setting", controller.RunLog{}.SaveArchiveSetting)
 
 			// 网络相关
 			cors.POST("/app/network/get-detail", controller.Network{}.GetDetail)
@@ -88,6 +122,7 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 			cors.POST("/app/network/prune", controller.Network{}.Prune)
 			cors.POST("/app/network/create", controller.Network{}.Create)
 			cors.POST("/app/network/delete", controller.Network{}.Delete)
+			cors.POST("/app/network/get-delete-impact", controller.Network{}.GetDeleteImpact)
 			cors.POST("/app/network/disconnect", controller.Network{}.Disconnect)
 			cors.POST("/app/network/connect", controller.Network{}.Connect)
 			cors.POST("/app/network/get-container-list", controller.Network{}.GetContainerList)
@@ -98,6 +133,7 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 			cors.POST("/app/volume/prune", controller.Volume{}.Prune)
 			cors.POST("/app/volume/create", controller.Volume{}.Create)
 			cors.POST("/app/volume/delete", controller.Volume{}.Delete)
+			cors.POST("/app/volume/get-delete-impact", controller.Volume{}.GetDeleteImpact)
 			cors.POST("/app/volume/backup", controller.Volume{}.Backup)
 			cors.POST("/app/volume/restore", controller.Volume{}.Restore)
 			cors.POST("/app/volume/get-backup-list", controller.Volume{}.GetBackupList)
@@ -115,6 +151,32 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 			cors.POST("/app/compose/container-destroy", controller.Compose{}.ContainerDestroy)
 			cors.POST("/app/compose/container-ctrl", controller.Compose{}.ContainerCtrl)
 			cors.POST("/app/compose/container-process-kill", controller.Compose{}.ContainerProcessKill)
+
+			// 告警相关
+			cors.POST("/app/alert/create", controller.Alert{}.Create)
+			cors.POST("/app/alert/get-list", controller.Alert{}.GetList)
+			cors.POST("/app/alert/delete", controller.Alert{}.Delete)
+			cors.POST("/app/alert/silence", controller.Alert{}.Silence)
+			cors.POST("/app/alert/get-firing-list", controller.Alert{}.GetFiringList)
+
+			// 计划任务
+			cors.POST("/app/cron/create", controller.Cron{}.Create)
+			cors.POST("/app/cron/get-list", controller.Cron{}.GetList)
+			cors.POST("/app/cron/delete", controller.Cron{}.Delete)
+			cors.POST("/app/cron/run-now", controller.Cron{}.RunNow)
+			cors.POST("/app/cron/get-log-list", controller.Cron{}.GetLogList)
+			cors.POST("/app/cron/get-next-run-time", controller.Cron{}.GetNextRunTime)
 		},
 	)
+
+	httpServer.RegisterRouters(func(engine *gin.Engine) {
+		wsCors := engine.Group("/ws/", common.CorsMiddleware{}.Process)
+
+		wsCors.GET("/app/log/stream/:md5", controller.RunLog{}.WsStream)
+	})
+
+	go logic.ContainerStat{}.MonitorLoop(time.Second * 30)
+	go logic.ImageUpdate{}.MonitorLoop()
+	go logic.LogIndex{}.MonitorLoop()
+	go logic.Cron{}.MonitorLoop()
 }
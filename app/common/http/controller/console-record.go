@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"errors"
+	"github.com/donknap/dpanel/app/common/logic"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/gin-gonic/gin"
+	"github.com/we7coreteam/w7-rangine-go/v2/src/http/controller"
+	"path/filepath"
+)
+
+type ConsoleRecord struct {
+	controller.Abstract
+}
+
+func (self ConsoleRecord) GetList(http *gin.Context) {
+	type ParamsValidate struct {
+		Env           string `json:"env"`
+		Username      string `json:"username"`
+		ContainerName string `json:"containerName"`
+		Page          int    `json:"page,default=1" binding:"omitempty,gt=0"`
+		PageSize      int    `json:"pageSize" binding:"omitempty,gt=1"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+	query := dao.ConsoleRecord.Order(dao.ConsoleRecord.ID.Desc())
+	if params.Env != "" {
+		query = query.Where(dao.ConsoleRecord.Env.Eq(params.Env))
+	}
+	if params.Username != "" {
+		query = query.Where(dao.ConsoleRecord.Username.Eq(params.Username))
+	}
+	if params.ContainerName != "" {
+		query = query.Where(dao.ConsoleRecord.ContainerName.Like("%" + params.ContainerName + "%"))
+	}
+	list, total, _ := query.FindByPage((params.Page-1)*params.PageSize, params.PageSize)
+	self.JsonResponseWithoutError(http, gin.H{
+		"total": total,
+		"page":  params.Page,
+		"list":  list,
+	})
+	return
+}
+
+// Play 返回 asciicast v2 格式的录像文件，前端使用 asciinema-player 回放
+func (self ConsoleRecord) Play(http *gin.Context) {
+	type ParamsValidate struct {
+		Id       int32 `json:"id" binding:"required"`
+		Download bool  `json:"download"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	row, _ := dao.ConsoleRecord.Where(dao.ConsoleRecord.ID.Eq(params.Id)).First()
+	if row == nil {
+		self.JsonResponseWithError(http, errors.New("录像不存在"), 500)
+		return
+	}
+	path, err := logic.ConsoleRecord{}.GetRealPath(row)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	http.Header("Content-Type", "application/x-asciicast")
+	if params.Download {
+		http.Header("Content-Disposition", "attachment; filename="+filepath.Base(row.FileName))
+	}
+	http.File(path)
+	return
+}
+
+func (self ConsoleRecord) Delete(http *gin.Context) {
+	type ParamsValidate struct {
+		Id []int32 `json:"id" binding:"required"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	list, _ := dao.ConsoleRecord.Where(dao.ConsoleRecord.ID.In(params.Id...)).Find()
+	for _, item := range list {
+		if err := (logic.ConsoleRecord{}).Delete(item); err != nil {
+			self.JsonResponseWithError(http, err, 500)
+			return
+		}
+	}
+	self.JsonSuccessResponse(http)
+	return
+}
+
+func (self ConsoleRecord) GetSetting(http *gin.Context) {
+	type ParamsValidate struct {
+		Env string `json:"env"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Env == "" {
+		params.Env = logic.DockerEnv{}.GetCurrentName()
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"env":     params.Env,
+		"setting": logic.ConsoleRecord{}.GetSetting(params.Env),
+	})
+	return
+}
+
+func (self ConsoleRecord) SaveSetting(http *gin.Context) {
+	type ParamsValidate struct {
+		Env           string `json:"env" binding:"required"`
+		Enable        bool   `json:"enable"`
+		RecordInput   bool   `json:"recordInput"`
+		RetentionDays int    `json:"retentionDays" binding:"omitempty,gte=0,lte=3650"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	err := logic.ConsoleRecord{}.SaveSetting(params.Env, &accessor.ConsoleRecordSetting{
+		Enable:        params.Enable,
+		RecordInput:   params.RecordInput,
+		RetentionDays: params.RetentionDays,
+	})
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonSuccessResponse(http)
+	return
+}
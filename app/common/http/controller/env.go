@@ -27,13 +27,7 @@ func (self Env) GetList(http *gin.Context) {
 			result = append(result, item)
 		}
 	}
-	currentName := "local"
-	for _, item := range result {
-		if item.Address == docker.Sdk.Client.DaemonHost() {
-			currentName = item.Name
-			break
-		}
-	}
+	currentName := logic.DockerEnv{}.GetCurrentName()
 	sort.Slice(result, func(i, j int) bool {
 		return result[i].Name < result[j].Name
 	})
@@ -9,13 +9,15 @@ import (
 	"github.com/donknap/dpanel/app/common/logic"
 	"github.com/donknap/dpanel/common/accessor"
 	"github.com/donknap/dpanel/common/dao"
-	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
 	"github.com/donknap/dpanel/common/service/docker"
 	"github.com/donknap/dpanel/common/service/notice"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/we7coreteam/w7-rangine-go/v2/pkg/support/facade"
 	"github.com/we7coreteam/w7-rangine-go/v2/src/http/controller"
+	"log/slog"
+	"strings"
 	"time"
 )
 
@@ -34,24 +36,40 @@ func (self Home) WsNotice(http *gin.Context) {
 		return
 	}
 
-	client, err := logic.NewClientConn(http, &logic.ClientOptions{})
+	statSubscriber := logic.NewContainerStatSubscriber()
+	client, err := logic.NewClientConn(http, &logic.ClientOptions{
+		Notice: true,
+		CloseHandler: func() {
+			statSubscriber.Close()
+		},
+		MessageHandler: map[string]func(message []byte){
+			"containerStats": statSubscriber.MessageHandler,
+		},
+	})
 	if err != nil {
 		self.JsonResponseWithError(http, err, 500)
 		return
 	}
+	statSubscriber.WithClient(client)
 	go client.ReadMessage()
 	go client.SendMessage()
 }
 
+// WsConsole 容器终端
+// 客户端发送 {"type": "input|resize|ping|exit", "content": {...}} 格式的消息，兼容旧的 console 类型
+// protocol=typed 时输出也使用 {"type": "ready|output|pong|exit", "data": ...} 格式，否则直接输出终端内容
 func (self Home) WsConsole(http *gin.Context) {
 	if !websocket.IsWebSocketUpgrade(http.Request) {
 		self.JsonResponseWithError(http, errors.New("please connect using websocket"), 500)
 		return
 	}
 	type ParamsValidate struct {
-		Id      string `uri:"id" binding:"required"`
-		Cmd     string `form:"cmd,default=/bin/sh"`
-		WorkDir string `form:"workDir"`
+		Id       string `uri:"id" binding:"required"`
+		Cmd      string `form:"cmd"`
+		WorkDir  string `form:"workDir"`
+		Cols     uint   `form:"cols"`
+		Rows     uint   `form:"rows"`
+		Protocol string `form:"protocol"`
 	}
 	params := ParamsValidate{}
 	if !self.Validate(http, &params) {
@@ -65,65 +83,213 @@ func (self Home) WsConsole(http *gin.Context) {
 	if params.WorkDir == "" {
 		params.WorkDir = "/"
 	}
-	exec, err := docker.Sdk.Client.ContainerExecCreate(docker.Sdk.Ctx, params.Id, container.ExecOptions{
+	if params.Cmd == "" {
+		shell, err := logic.Console{}.DetectShell(params.Id)
+		if err != nil {
+			notice.Message{}.Error("console", err.Error())
+			self.JsonResponseWithError(http, err, 500)
+			return
+		}
+		params.Cmd = shell
+	}
+	typed := params.Protocol == "typed"
+	execOption := container.ExecOptions{
 		Privileged:   true,
 		Tty:          true,
 		AttachStdin:  true,
 		AttachStdout: true,
 		AttachStderr: true,
-		Cmd: []string{
-			params.Cmd,
-		},
-		WorkingDir: params.WorkDir,
-	})
+		Cmd:          function.CommandSplit(params.Cmd),
+		WorkingDir:   params.WorkDir,
+	}
+	if params.Cols > 0 && params.Rows > 0 {
+		execOption.ConsoleSize = &[2]uint{params.Rows, params.Cols}
+	}
+	exec, err := docker.Sdk.Client.ContainerExecCreate(docker.Sdk.Ctx, params.Id, execOption)
 	if err != nil {
 		notice.Message{}.Error("console", err.Error())
 		self.JsonResponseWithError(http, err, 500)
 		return
 	}
 	shell, err := docker.Sdk.Client.ContainerExecAttach(docker.Sdk.Ctx, exec.ID, container.ExecStartOptions{
-		Tty: true,
+		Tty:         true,
+		ConsoleSize: execOption.ConsoleSize,
 	})
 	if err != nil {
 		self.JsonResponseWithError(http, err, 500)
 		return
 	}
-	type command struct {
-		Type    string `json:"type"`
-		Content struct {
-			Command string `json:"command"`
-		} `json:"content"`
+	containerInfo, _ := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, params.Id)
+	username := logic.User{}.GetUsernameByContext(http)
+	recorder, err := logic.ConsoleRecord{}.NewRecorder(&logic.ConsoleRecordOption{
+		Username:      username,
+		ContainerName: containerInfo.Name,
+		ContainerID:   containerInfo.ID,
+		Cmd:           params.Cmd,
+		WorkDir:       params.WorkDir,
+	})
+	if err != nil {
+		// 录像失败不影响使用终端
+		slog.Debug("console record", "create", err.Error())
 	}
-	client, err := logic.NewClientConn(http, &logic.ClientOptions{
-		CloseHandler: func() {
-			shell.Close()
-		},
-		MessageHandler: map[string]func(message []byte){
-			"console": func(message []byte) {
-				var cmd command
-				json.Unmarshal(message, &cmd)
-				shell.Conn.Write([]byte(cmd.Content.Command))
-			},
-		},
+	if execOption.ConsoleSize != nil {
+		recorder.Resize(params.Cols, params.Rows)
+	}
+	session := logic.NewConsoleSession(&logic.ConsoleSessionOption{
+		ExecId:        exec.ID,
+		ContainerName: strings.TrimPrefix(containerInfo.Name, "/"),
+		Cmd:           params.Cmd,
+		Shell:         shell,
+		Recorder:      recorder,
 	})
+	client, err := self.joinConsoleSession(http, session, username, logic.ConsoleModeOwner, typed)
 	if err != nil {
+		session.Close(&logic.ConsoleExitMessage{ExitCode: -1, Error: err.Error()})
 		self.JsonResponseWithError(http, err, 500)
 		return
 	}
+	if typed {
+		client.WriteMessage(logic.ConsoleMessageReady, gin.H{
+			"execId":    exec.ID,
+			"shell":     params.Cmd,
+			"sessionId": session.Id,
+			"mode":      logic.ConsoleModeOwner,
+		})
+	}
 	go client.ReadMessage()
 	go func() {
-		out := make([]byte, 2028)
+		out := make([]byte, 2048)
 		for {
 			n, err := shell.Conn.Read(out)
+			if n > 0 {
+				session.Output(out[:n])
+			}
 			if err != nil {
-				return
+				break
 			}
-			processedOutput := string(out[:n])
-			client.Conn.WriteMessage(websocket.TextMessage, []byte(processedOutput))
 		}
+		// 输出流结束说明进程已经退出或是会话被关闭
+		result := &logic.ConsoleExitMessage{}
+		result.ExitCode, err = logic.Console{}.GetExitCode(exec.ID)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		session.Close(result)
 	}()
 }
 
+// WsConsoleJoin 加入其它用户共享的终端会话，需要在 token 参数中携带登录凭证
+func (self Home) WsConsoleJoin(http *gin.Context) {
+	if !websocket.IsWebSocketUpgrade(http.Request) {
+		self.JsonResponseWithError(http, errors.New("please connect using websocket"), 500)
+		return
+	}
+	type ParamsValidate struct {
+		SessionId string `uri:"sessionId" binding:"required"`
+		Mode      string `form:"mode" binding:"omitempty,oneof=read write"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	username := logic.User{}.GetUsernameByContext(http)
+	if username == "" {
+		self.JsonResponseWithError(http, errors.New("请先登录"), 401)
+		return
+	}
+	if params.Mode == "" {
+		params.Mode = logic.ConsoleModeRead
+	}
+	session, err := logic.GetConsoleSession(params.SessionId)
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	client, err := self.joinConsoleSession(http, session, username, params.Mode, true)
+	if err != nil {
+		// 连接已经升级为 ws，只能通过消息告知失败原因
+		if client != nil {
+			client.WriteMessage(logic.ConsoleMessageExit, &logic.ConsoleExitMessage{ExitCode: -1, Error: err.Error()})
+			_ = client.Close()
+			return
+		}
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	client.WriteMessage(logic.ConsoleMessageReady, gin.H{
+		"execId":    session.ExecId,
+		"shell":     session.Cmd,
+		"sessionId": session.Id,
+		"mode":      session.GetMode(client.Id),
+	})
+	go client.ReadMessage()
+}
+
+func (self Home) joinConsoleSession(http *gin.Context, session *logic.ConsoleSession, username string, mode string, typed bool) (*logic.Client, error) {
+	type message struct {
+		Type    string `json:"type"`
+		Content struct {
+			Command string `json:"command"`
+			Data    string `json:"data"`
+			logic.ConsoleResizeMessage
+			logic.ConsoleShareMessage
+			logic.ConsoleRevokeMessage
+		} `json:"content"`
+	}
+	parse := func(content []byte) *message {
+		msg := &message{}
+		_ = json.Unmarshal(content, msg)
+		return msg
+	}
+	var client *logic.Client
+	client, err := logic.NewClientConn(http, &logic.ClientOptions{
+		CloseHandler: func() {
+			session.Leave(client.Id)
+		},
+		MessageHandler: map[string]func(message []byte){
+			"console": func(content []byte) {
+				session.Input(client.Id, []byte(parse(content).Content.Command))
+			},
+			logic.ConsoleMessageInput: func(content []byte) {
+				session.Input(client.Id, []byte(parse(content).Content.Data))
+			},
+			logic.ConsoleMessageResize: func(content []byte) {
+				session.Resize(client.Id, &parse(content).Content.ConsoleResizeMessage)
+			},
+			logic.ConsoleMessagePing: func(content []byte) {
+				client.WriteMessage(logic.ConsoleMessagePong, time.Now().Unix())
+			},
+			logic.ConsoleMessageExit: func(content []byte) {
+				_ = client.Close()
+			},
+			logic.ConsoleMessageShare: func(content []byte) {
+				if err := session.Share(client.Id, parse(content).Content.Mode); err != nil {
+					client.WriteMessage("error", err.Error())
+				}
+			},
+			logic.ConsoleMessageRevoke: func(content []byte) {
+				if err := session.Revoke(client.Id, parse(content).Content.ClientId); err != nil {
+					client.WriteMessage("error", err.Error())
+				}
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = session.Join(client, username, mode, typed); err != nil {
+		return client, err
+	}
+	return client, nil
+}
+
+func (self Home) GetConsoleSessionList(http *gin.Context) {
+	self.JsonResponseWithoutError(http, gin.H{
+		"list": logic.GetConsoleSessionList(),
+	})
+	return
+}
+
 func (self Home) Info(http *gin.Context) {
 	dpanelContainerInfo, _ := docker.Sdk.ContainerInfo(facade.GetConfig().GetString("app.name"))
 	info, err := docker.Sdk.Client.Info(docker.Sdk.Ctx)
@@ -135,27 +301,7 @@ func (self Home) Info(http *gin.Context) {
 
 	// 有些设备的docker获取磁盘占用比较耗时，跑一下后台协程去获取数据
 	go func() {
-		diskUsage, err := docker.Sdk.Client.DiskUsage(docker.Sdk.Ctx, types.DiskUsageOptions{
-			Types: []types.DiskUsageObject{
-				types.ContainerObject,
-				types.ImageObject,
-				types.VolumeObject,
-				types.BuildCacheObject,
-			},
-		})
-		if err == nil {
-			logic.Setting{}.Save(&entity.Setting{
-				GroupName: logic.SettingGroupSetting,
-				Name:      logic.SettingGroupSettingDiskUsage,
-				Value: &accessor.SettingValueOption{
-					DiskUsage: accessor.DiskUsage{
-						Usage:     diskUsage,
-						UpdatedAt: time.Now(),
-					},
-				},
-			})
-		}
-		return
+		_, _ = logic.DiskUsage{}.Refresh()
 	}()
 
 	diskUsage := accessor.DiskUsage{
@@ -202,6 +348,59 @@ func (self Home) GetStatList(http *gin.Context) {
 	return
 }
 
+func (self Home) GetHostInfo(http *gin.Context) {
+	metrics, err := logic.Host{}.GetMetrics()
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"info": metrics,
+	})
+	return
+}
+
+func (self Home) GetDiskUsageHistory(http *gin.Context) {
+	type ParamsValidate struct {
+		Days int `json:"days"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.Days <= 0 {
+		params.Days = 30
+	}
+	list, trend := logic.DiskUsage{}.GetHistory(time.Now().Local().AddDate(0, 0, -params.Days))
+	self.JsonResponseWithoutError(http, gin.H{
+		"list":  list,
+		"trend": trend,
+	})
+	return
+}
+
+func (self Home) GetCleanRecommend(http *gin.Context) {
+	type ParamsValidate struct {
+		StoppedDays int `json:"stoppedDays"`
+	}
+	params := ParamsValidate{}
+	if !self.Validate(http, &params) {
+		return
+	}
+	if params.StoppedDays <= 0 {
+		params.StoppedDays = 7
+	}
+	diskUsage, err := logic.DiskUsage{}.Refresh()
+	if err != nil {
+		self.JsonResponseWithError(http, err, 500)
+		return
+	}
+	self.JsonResponseWithoutError(http, gin.H{
+		"list": logic.DiskUsage{}.GetRecommend(diskUsage, params.StoppedDays),
+	})
+	return
+}
+
 func (self Home) UpgradeScript(http *gin.Context) {
 	containerRow, err := docker.Sdk.ContainerInfo(facade.GetConfig().GetString("app.name"))
 	if err != nil {
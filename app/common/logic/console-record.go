@@ -0,0 +1,250 @@
+package logic
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/service/storage"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	consoleRecordDefaultWidth  = 80
+	consoleRecordDefaultHeight = 24
+)
+
+type ConsoleRecordOption struct {
+	Username      string
+	ContainerName string
+	ContainerID   string
+	Cmd           string
+	WorkDir       string
+}
+
+type ConsoleRecord struct {
+}
+
+// GetSetting 获取指定 docker 环境的录像配置，env 为空时使用当前环境
+func (self ConsoleRecord) GetSetting(env string) *accessor.ConsoleRecordSetting {
+	if env == "" {
+		env = DockerEnv{}.GetCurrentName()
+	}
+	row, err := Setting{}.GetValue(SettingGroupSetting, SettingGroupSettingConsoleRecord)
+	if err == nil && row.Value != nil && row.Value.ConsoleRecord != nil {
+		if setting, ok := row.Value.ConsoleRecord[env]; ok && setting != nil {
+			return setting
+		}
+	}
+	return &accessor.ConsoleRecordSetting{}
+}
+
+func (self ConsoleRecord) SaveSetting(env string, setting *accessor.ConsoleRecordSetting) error {
+	row, err := Setting{}.GetValue(SettingGroupSetting, SettingGroupSettingConsoleRecord)
+	if err != nil || row.Value == nil || row.Value.ConsoleRecord == nil {
+		row = &entity.Setting{
+			GroupName: SettingGroupSetting,
+			Name:      SettingGroupSettingConsoleRecord,
+			Value: &accessor.SettingValueOption{
+				ConsoleRecord: make(map[string]*accessor.ConsoleRecordSetting),
+			},
+		}
+	}
+	row.Value.ConsoleRecord[env] = setting
+	return Setting{}.Save(row)
+}
+
+// NewRecorder 当前环境开启录像时创建录像文件，未开启时返回 nil
+// ConsoleRecorder 的方法可以在 nil 上调用，调用方不需要额外判断
+func (self ConsoleRecord) NewRecorder(option *ConsoleRecordOption) (*ConsoleRecorder, error) {
+	env := DockerEnv{}.GetCurrentName()
+	setting := self.GetSetting(env)
+	if !setting.Enable {
+		return nil, nil
+	}
+	self.Purge(env, setting)
+
+	startAt := time.Now()
+	containerName := strings.TrimPrefix(option.ContainerName, "/")
+	fileName := filepath.Join(env, containerName, fmt.Sprintf("%s-%d.cast", containerName, startAt.UnixNano()))
+	path := storage.Local{}.GetConsoleRecordPath(fileName)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	recorder := &ConsoleRecorder{
+		row: &entity.ConsoleRecord{
+			Username:      option.Username,
+			Env:           env,
+			ContainerName: containerName,
+			ContainerID:   option.ContainerID,
+			Cmd:           option.Cmd,
+			WorkDir:       option.WorkDir,
+			RecordInput:   setting.RecordInput,
+			FileName:      fileName,
+			CreatedAt:     startAt,
+		},
+		file:    file,
+		writer:  bufio.NewWriter(file),
+		startAt: startAt,
+		pending: make(map[string][]byte),
+	}
+	// asciicast v2 第一行为头信息，之后每行为一个 [时间, 类型, 数据] 事件
+	header, _ := json.Marshal(map[string]interface{}{
+		"version":   2,
+		"width":     consoleRecordDefaultWidth,
+		"height":    consoleRecordDefaultHeight,
+		"timestamp": startAt.Unix(),
+		"command":   option.Cmd,
+		"title":     fmt.Sprintf("%s@%s", option.Username, containerName),
+		"env": map[string]string{
+			"SHELL": option.Cmd,
+			"TERM":  "xterm",
+		},
+	})
+	if _, err = recorder.writer.Write(append(header, '\n')); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return nil, err
+	}
+	if err = dao.ConsoleRecord.Create(recorder.row); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// Purge 删除超过保留天数的录像
+func (self ConsoleRecord) Purge(env string, setting *accessor.ConsoleRecordSetting) {
+	if setting.RetentionDays <= 0 {
+		return
+	}
+	list, _ := dao.ConsoleRecord.Where(
+		dao.ConsoleRecord.Env.Eq(env),
+		dao.ConsoleRecord.CreatedAt.Lt(time.Now().AddDate(0, 0, -setting.RetentionDays)),
+	).Find()
+	for _, item := range list {
+		_ = self.Delete(item)
+	}
+}
+
+func (self ConsoleRecord) Delete(row *entity.ConsoleRecord) error {
+	if path, err := self.GetRealPath(row); err == nil {
+		if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	_, err := dao.ConsoleRecord.Where(dao.ConsoleRecord.ID.Eq(row.ID)).Delete()
+	return err
+}
+
+// GetRealPath 返回录像文件的绝对路径，防止文件名中包含 ../ 越出存储目录
+func (self ConsoleRecord) GetRealPath(row *entity.ConsoleRecord) (string, error) {
+	root := storage.Local{}.GetConsoleRecordPath("")
+	path := storage.Local{}.GetConsoleRecordPath(row.FileName)
+	if !strings.HasPrefix(path, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid record file %s", row.FileName)
+	}
+	return path, nil
+}
+
+type ConsoleRecorder struct {
+	row     *entity.ConsoleRecord
+	file    *os.File
+	writer  *bufio.Writer
+	startAt time.Time
+	pending map[string][]byte // 被截断的 utf8 字符，留到下次写入
+	closed  bool
+	lock    sync.Mutex
+}
+
+func (self *ConsoleRecorder) Output(data []byte) {
+	self.write("o", data)
+}
+
+func (self *ConsoleRecorder) Input(data []byte) {
+	if self == nil || !self.row.RecordInput {
+		return
+	}
+	self.write("i", data)
+}
+
+func (self *ConsoleRecorder) Resize(width, height uint) {
+	self.write("r", []byte(fmt.Sprintf("%dx%d", width, height)))
+}
+
+func (self *ConsoleRecorder) Close() {
+	if self == nil {
+		return
+	}
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.closed {
+		return
+	}
+	self.closed = true
+	_ = self.writer.Flush()
+	_ = self.file.Close()
+
+	row := &entity.ConsoleRecord{
+		Duration: self.elapsed(),
+	}
+	if stat, err := os.Stat(self.file.Name()); err == nil {
+		row.Size = stat.Size()
+	}
+	_, err := dao.ConsoleRecord.Where(dao.ConsoleRecord.ID.Eq(self.row.ID)).Updates(row)
+	if err != nil {
+		slog.Debug("console record", "update", err.Error())
+	}
+}
+
+func (self *ConsoleRecorder) write(eventType string, data []byte) {
+	if self == nil {
+		return
+	}
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.closed {
+		return
+	}
+	data = append(self.pending[eventType], data...)
+	// 终端输出按字节读取，末尾的多字节字符可能不完整
+	end := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				end = i
+			}
+			break
+		}
+	}
+	self.pending[eventType] = append([]byte{}, data[end:]...)
+	if end == 0 {
+		return
+	}
+	event, _ := json.Marshal([]interface{}{
+		self.elapsed(),
+		eventType,
+		strings.ToValidUTF8(string(data[:end]), "�"),
+	})
+	if _, err := self.writer.Write(append(event, '\n')); err != nil {
+		slog.Debug("console record", "write", err.Error())
+	}
+}
+
+func (self *ConsoleRecorder) elapsed() float64 {
+	return math.Round(time.Since(self.startAt).Seconds()*1e6) / 1e6
+}
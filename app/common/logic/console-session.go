@@ -0,0 +1,361 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/donknap/dpanel/common/function"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	ConsoleModeOwner = "owner"
+	ConsoleModeWrite = "write"
+	ConsoleModeRead  = "read"
+
+	ConsoleMessageShare        = "share"
+	ConsoleMessageRevoke       = "revoke"
+	ConsoleMessageParticipants = "participants"
+)
+
+var (
+	consoleSessionCollect = make(map[string]*ConsoleSession)
+	consoleSessionLock    = sync.RWMutex{}
+)
+
+type ConsoleSessionOption struct {
+	ExecId        string
+	ContainerName string
+	Cmd           string
+	Shell         types.HijackedResponse
+	Recorder      *ConsoleRecorder
+}
+
+type ConsoleShareMessage struct {
+	Mode string `json:"mode"` // 允许加入者使用的最高权限，为空时关闭共享
+}
+
+type ConsoleRevokeMessage struct {
+	ClientId string `json:"clientId"`
+}
+
+type ConsoleParticipant struct {
+	ClientId string    `json:"clientId"`
+	Username string    `json:"username"`
+	Mode     string    `json:"mode"`
+	JoinAt   time.Time `json:"joinAt"`
+	client   *Client
+	typed    bool // 是否使用 typed 协议，旧的客户端直接接收终端输出
+}
+
+type ConsoleSessionInfo struct {
+	Id            string                `json:"id"`
+	ContainerName string                `json:"containerName"`
+	Cmd           string                `json:"cmd"`
+	Owner         string                `json:"owner"`
+	ShareMode     string                `json:"shareMode"`
+	CreatedAt     time.Time             `json:"createdAt"`
+	Participants  []*ConsoleParticipant `json:"participants"`
+}
+
+// ConsoleSession 一个终端进程，可以被多个连接共享
+// 所有者断开时结束会话，其它参与者只能按所有者开放的权限加入
+type ConsoleSession struct {
+	Id            string
+	ExecId        string
+	ContainerName string
+	Cmd           string
+	CreatedAt     time.Time
+	shell         types.HijackedResponse
+	recorder      *ConsoleRecorder
+	owner         *ConsoleParticipant
+	shareMode     string
+	participants  map[string]*ConsoleParticipant
+	closed        bool
+	lock          sync.RWMutex
+}
+
+func NewConsoleSession(option *ConsoleSessionOption) *ConsoleSession {
+	session := &ConsoleSession{
+		Id:            function.GetRandomString(32),
+		ExecId:        option.ExecId,
+		ContainerName: option.ContainerName,
+		Cmd:           option.Cmd,
+		CreatedAt:     time.Now(),
+		shell:         option.Shell,
+		recorder:      option.Recorder,
+		participants:  make(map[string]*ConsoleParticipant),
+	}
+	consoleSessionLock.Lock()
+	consoleSessionCollect[session.Id] = session
+	consoleSessionLock.Unlock()
+	return session
+}
+
+func GetConsoleSession(id string) (*ConsoleSession, error) {
+	consoleSessionLock.RLock()
+	defer consoleSessionLock.RUnlock()
+	if session, ok := consoleSessionCollect[id]; ok {
+		return session, nil
+	}
+	return nil, errors.New("终端会话不存在或已结束")
+}
+
+func GetConsoleSessionList() []*ConsoleSessionInfo {
+	consoleSessionLock.RLock()
+	result := make([]*ConsoleSessionInfo, 0, len(consoleSessionCollect))
+	for _, session := range consoleSessionCollect {
+		result = append(result, session.Info())
+	}
+	consoleSessionLock.RUnlock()
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result
+}
+
+// Join 加入会话，第一个加入的连接为所有者
+func (self *ConsoleSession) Join(client *Client, username string, mode string, typed bool) (*ConsoleParticipant, error) {
+	self.lock.Lock()
+	if self.closed {
+		self.lock.Unlock()
+		return nil, errors.New("终端会话已结束")
+	}
+	participant := &ConsoleParticipant{
+		ClientId: client.Id,
+		Username: username,
+		JoinAt:   time.Now(),
+		client:   client,
+		typed:    typed,
+	}
+	if self.owner == nil {
+		participant.Mode = ConsoleModeOwner
+		self.owner = participant
+	} else {
+		switch {
+		case self.shareMode == "":
+			self.lock.Unlock()
+			return nil, errors.New("会话未开启共享")
+		case mode == ConsoleModeWrite && self.shareMode == ConsoleModeWrite:
+			participant.Mode = ConsoleModeWrite
+		default:
+			participant.Mode = ConsoleModeRead
+		}
+	}
+	self.participants[client.Id] = participant
+	self.lock.Unlock()
+
+	self.broadcastParticipants()
+	return participant, nil
+}
+
+// Leave 连接断开时调用，所有者离开时结束整个会话
+func (self *ConsoleSession) Leave(clientId string) {
+	self.lock.Lock()
+	participant, ok := self.participants[clientId]
+	if !ok {
+		self.lock.Unlock()
+		return
+	}
+	delete(self.participants, clientId)
+	self.lock.Unlock()
+
+	if participant.Mode == ConsoleModeOwner {
+		self.Close(&ConsoleExitMessage{
+			ExitCode: -1,
+			Error:    "会话所有者已断开",
+		})
+		return
+	}
+	self.broadcastParticipants()
+}
+
+// Share 所有者设置共享权限，关闭共享时移除其它参与者
+func (self *ConsoleSession) Share(clientId string, mode string) error {
+	if !function.InArray([]string{"", ConsoleModeRead, ConsoleModeWrite}, mode) {
+		return fmt.Errorf("unknown share mode %s", mode)
+	}
+	if !self.isOwner(clientId) {
+		return errors.New("只有会话所有者可以修改共享设置")
+	}
+	self.lock.Lock()
+	self.shareMode = mode
+	revokeList := make([]*ConsoleParticipant, 0)
+	for _, item := range self.participants {
+		if item.Mode == ConsoleModeOwner {
+			continue
+		}
+		if mode == "" {
+			revokeList = append(revokeList, item)
+		} else if mode == ConsoleModeRead {
+			item.Mode = ConsoleModeRead
+		}
+	}
+	self.lock.Unlock()
+
+	for _, item := range revokeList {
+		self.kick(item, "会话已关闭共享")
+	}
+	self.broadcastParticipants()
+	return nil
+}
+
+// Revoke 所有者移除指定的参与者
+func (self *ConsoleSession) Revoke(clientId string, targetClientId string) error {
+	if !self.isOwner(clientId) {
+		return errors.New("只有会话所有者可以移除参与者")
+	}
+	self.lock.RLock()
+	participant, ok := self.participants[targetClientId]
+	self.lock.RUnlock()
+	if !ok || participant.Mode == ConsoleModeOwner {
+		return errors.New("参与者不存在")
+	}
+	self.kick(participant, "已被会话所有者移除")
+	return nil
+}
+
+// Input 只有所有者与可写的参与者可以输入
+func (self *ConsoleSession) Input(clientId string, data []byte) {
+	if !self.canWrite(clientId) {
+		return
+	}
+	self.recorder.Input(data)
+	_, _ = self.shell.Conn.Write(data)
+}
+
+func (self *ConsoleSession) Resize(clientId string, size *ConsoleResizeMessage) {
+	if !self.canWrite(clientId) {
+		return
+	}
+	if err := (Console{}).Resize(self.ExecId, size); err != nil {
+		slog.Debug("console", "resize", err.Error())
+		return
+	}
+	self.recorder.Resize(size.Cols, size.Rows)
+}
+
+// Output 将终端输出发送给所有参与者
+func (self *ConsoleSession) Output(data []byte) {
+	self.recorder.Output(data)
+	for _, item := range self.getParticipants() {
+		if item.typed {
+			item.client.WriteMessage(ConsoleMessageOutput, string(data))
+		} else {
+			item.client.WriteRawMessage(data)
+		}
+	}
+}
+
+// Close 结束会话并断开所有参与者
+func (self *ConsoleSession) Close(result *ConsoleExitMessage) {
+	self.lock.Lock()
+	if self.closed {
+		self.lock.Unlock()
+		return
+	}
+	self.closed = true
+	participants := make([]*ConsoleParticipant, 0, len(self.participants))
+	for _, item := range self.participants {
+		participants = append(participants, item)
+	}
+	self.participants = make(map[string]*ConsoleParticipant)
+	self.lock.Unlock()
+
+	consoleSessionLock.Lock()
+	delete(consoleSessionCollect, self.Id)
+	consoleSessionLock.Unlock()
+
+	self.shell.Close()
+	self.recorder.Close()
+	for _, item := range participants {
+		if item.typed {
+			item.client.WriteMessage(ConsoleMessageExit, result)
+		} else {
+			item.client.WriteRawMessage([]byte(fmt.Sprintf("\r\n[进程已退出，退出码 %d]\r\n", result.ExitCode)))
+		}
+		_ = item.client.Close()
+	}
+}
+
+func (self *ConsoleSession) Info() *ConsoleSessionInfo {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+	info := &ConsoleSessionInfo{
+		Id:            self.Id,
+		ContainerName: self.ContainerName,
+		Cmd:           self.Cmd,
+		ShareMode:     self.shareMode,
+		CreatedAt:     self.CreatedAt,
+		Participants:  make([]*ConsoleParticipant, 0, len(self.participants)),
+	}
+	if self.owner != nil {
+		info.Owner = self.owner.Username
+	}
+	for _, item := range self.participants {
+		participant := *item
+		info.Participants = append(info.Participants, &participant)
+	}
+	sort.Slice(info.Participants, func(i, j int) bool {
+		return info.Participants[i].JoinAt.Before(info.Participants[j].JoinAt)
+	})
+	return info
+}
+
+func (self *ConsoleSession) GetMode(clientId string) string {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+	if participant, ok := self.participants[clientId]; ok {
+		return participant.Mode
+	}
+	return ""
+}
+
+func (self *ConsoleSession) kick(participant *ConsoleParticipant, reason string) {
+	self.lock.Lock()
+	delete(self.participants, participant.ClientId)
+	self.lock.Unlock()
+	participant.client.WriteMessage(ConsoleMessageExit, &ConsoleExitMessage{
+		ExitCode: -1,
+		Error:    reason,
+	})
+	_ = participant.client.Close()
+	self.broadcastParticipants()
+}
+
+func (self *ConsoleSession) broadcastParticipants() {
+	info := self.Info()
+	for _, item := range info.Participants {
+		if item.typed {
+			item.client.WriteMessage(ConsoleMessageParticipants, info)
+		}
+	}
+}
+
+func (self *ConsoleSession) getParticipants() []*ConsoleParticipant {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+	result := make([]*ConsoleParticipant, 0, len(self.participants))
+	for _, item := range self.participants {
+		result = append(result, item)
+	}
+	return result
+}
+
+func (self *ConsoleSession) isOwner(clientId string) bool {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+	return self.owner != nil && self.owner.ClientId == clientId && !self.closed
+}
+
+func (self *ConsoleSession) canWrite(clientId string) bool {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+	if participant, ok := self.participants[clientId]; ok {
+		return participant.Mode == ConsoleModeOwner || participant.Mode == ConsoleModeWrite
+	}
+	return false
+}
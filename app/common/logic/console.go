@@ -0,0 +1,73 @@
+package logic
+
+import (
+	"errors"
+	"github.com/docker/docker/api/types/container"
+	"github.com/donknap/dpanel/common/service/docker"
+	"time"
+)
+
+// 未指定命令时按顺序探测容器中可用的 shell
+var consoleShellList = []string{
+	"/bin/bash",
+	"/bin/sh",
+	"/bin/ash",
+}
+
+const (
+	ConsoleMessageInput  = "input"
+	ConsoleMessageResize = "resize"
+	ConsoleMessagePing   = "ping"
+	ConsoleMessageExit   = "exit"
+	ConsoleMessageReady  = "ready"
+	ConsoleMessageOutput = "output"
+	ConsoleMessagePong   = "pong"
+)
+
+type ConsoleResizeMessage struct {
+	Cols uint `json:"cols"`
+	Rows uint `json:"rows"`
+}
+
+type ConsoleExitMessage struct {
+	ExitCode int    `json:"exitCode"`
+	Error    string `json:"error,omitempty"`
+}
+
+type Console struct {
+}
+
+// DetectShell 通过查看文件是否存在探测 shell，不需要在容器中执行命令
+func (self Console) DetectShell(md5 string) (string, error) {
+	for _, shell := range consoleShellList {
+		if _, err := docker.Sdk.Client.ContainerStatPath(docker.Sdk.Ctx, md5, shell); err == nil {
+			return shell, nil
+		}
+	}
+	return "", errors.New("容器中没有可用的 shell，请手动指定命令")
+}
+
+func (self Console) Resize(execId string, size *ConsoleResizeMessage) error {
+	if size.Cols == 0 || size.Rows == 0 {
+		return nil
+	}
+	return docker.Sdk.Client.ContainerExecResize(docker.Sdk.Ctx, execId, container.ResizeOptions{
+		Height: size.Rows,
+		Width:  size.Cols,
+	})
+}
+
+// GetExitCode 输出流结束后进程可能还没有完全退出，稍等片刻再获取退出码
+func (self Console) GetExitCode(execId string) (int, error) {
+	for i := 0; i < 10; i++ {
+		info, err := docker.Sdk.Client.ContainerExecInspect(docker.Sdk.Ctx, execId)
+		if err != nil {
+			return -1, err
+		}
+		if !info.Running {
+			return info.ExitCode, nil
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	return -1, errors.New("进程仍在运行")
+}
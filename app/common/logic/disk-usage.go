@@ -0,0 +1,335 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/donknap/dpanel/common/accessor"
+	"github.com/donknap/dpanel/common/dao"
+	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/function"
+	"github.com/donknap/dpanel/common/service/docker"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	DiskCleanDanglingImage    = "danglingImage"
+	DiskCleanStoppedContainer = "stoppedContainer"
+	DiskCleanUnusedVolume     = "unusedVolume"
+	DiskCleanBuildCache       = "buildCache"
+
+	diskUsageSnapshotInterval = time.Hour
+	diskUsageRetention        = time.Hour * 24 * 90
+)
+
+type DiskUsageTrend struct {
+	Type     string `json:"type"`
+	First    int64  `json:"first"`
+	Last     int64  `json:"last"`
+	Growth   int64  `json:"growth"`
+	DayGrown int64  `json:"dayGrown"` // 平均每天增长
+}
+
+type DiskCleanItem struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+type DiskCleanRecommend struct {
+	Type  string          `json:"type"`
+	Title string          `json:"title"`
+	Size  int64           `json:"size"` // 预计可以释放的空间
+	Items []DiskCleanItem `json:"items"`
+}
+
+type DiskCleanResult struct {
+	Type      string            `json:"type"`
+	Reclaimed int64             `json:"reclaimed"`
+	Removed   []string          `json:"removed"`
+	Error     map[string]string `json:"error"`
+}
+
+type DiskUsage struct {
+}
+
+// Refresh 获取 docker 磁盘占用，保存最新的数据并按间隔记录一份快照
+func (self DiskUsage) Refresh() (*types.DiskUsage, error) {
+	diskUsage, err := docker.Sdk.Client.DiskUsage(docker.Sdk.Ctx, types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{
+			types.ContainerObject,
+			types.ImageObject,
+			types.VolumeObject,
+			types.BuildCacheObject,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	_ = Setting{}.Save(&entity.Setting{
+		GroupName: SettingGroupSetting,
+		Name:      SettingGroupSettingDiskUsage,
+		Value: &accessor.SettingValueOption{
+			DiskUsage: accessor.DiskUsage{
+				Usage:     diskUsage,
+				UpdatedAt: time.Now(),
+			},
+		},
+	})
+
+	// 快照按 docker 环境区分，切换环境后不会混在一起
+	now := time.Now().Local()
+	envName := DockerEnv{}.GetCurrentName()
+	lastRow, _ := dao.DiskUsage.Where(dao.DiskUsage.Env.Eq(envName)).Order(dao.DiskUsage.ID.Desc()).First()
+	if lastRow == nil || now.Sub(lastRow.CreatedAt) >= diskUsageSnapshotInterval {
+		snapshot := self.snapshot(&diskUsage)
+		snapshot.Env = envName
+		snapshot.CreatedAt = now
+		_ = dao.DiskUsage.Create(snapshot)
+		_, _ = dao.DiskUsage.Where(dao.DiskUsage.CreatedAt.Lt(now.Add(-diskUsageRetention))).Delete()
+	}
+	return &diskUsage, nil
+}
+
+// MonitorLoop 定时记录磁盘占用快照
+func (self DiskUsage) MonitorLoop() {
+	ticker := time.NewTicker(diskUsageSnapshotInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		_, err := self.Refresh()
+		if err != nil {
+			slog.Debug("disk usage", "refresh", err.Error())
+		}
+	}
+}
+
+// GetHistory 获取某个时间之后的快照及各分类的增长情况
+func (self DiskUsage) GetHistory(since time.Time) ([]*entity.DiskUsage, []*DiskUsageTrend) {
+	list, _ := dao.DiskUsage.Where(
+		dao.DiskUsage.Env.Eq(DockerEnv{}.GetCurrentName()),
+		dao.DiskUsage.CreatedAt.Gte(since),
+	).Order(dao.DiskUsage.ID.Asc()).Find()
+	trend := make([]*DiskUsageTrend, 0)
+	if len(list) == 0 {
+		return list, trend
+	}
+	first, last := list[0], list[len(list)-1]
+	days := last.CreatedAt.Sub(first.CreatedAt).Hours() / 24
+	for _, item := range []struct {
+		Type        string
+		First, Last int64
+	}{
+		{Type: "image", First: first.Image, Last: last.Image},
+		{Type: "container", First: first.Container, Last: last.Container},
+		{Type: "volume", First: first.Volume, Last: last.Volume},
+		{Type: "buildCache", First: first.BuildCache, Last: last.BuildCache},
+	} {
+		row := &DiskUsageTrend{
+			Type:   item.Type,
+			First:  item.First,
+			Last:   item.Last,
+			Growth: item.Last - item.First,
+		}
+		if days >= 1 {
+			row.DayGrown = int64(float64(row.Growth) / days)
+		}
+		trend = append(trend, row)
+	}
+	return list, trend
+}
+
+// GetRecommend 根据当前磁盘占用计算可以清理的项目，stoppedDays 为容器停止超过多少天
+func (self DiskUsage) GetRecommend(diskUsage *types.DiskUsage, stoppedDays int) []*DiskCleanRecommend {
+	danglingImage := &DiskCleanRecommend{
+		Type:  DiskCleanDanglingImage,
+		Title: "未使用的悬空镜像",
+		Items: make([]DiskCleanItem, 0),
+	}
+	for _, item := range diskUsage.Images {
+		if item.Containers > 0 || !self.isDangling(item) {
+			continue
+		}
+		size := item.Size
+		if item.SharedSize > 0 {
+			size -= item.SharedSize
+		}
+		danglingImage.Size += size
+		danglingImage.Items = append(danglingImage.Items, DiskCleanItem{
+			Id:   item.ID,
+			Name: item.ID,
+			Size: size,
+		})
+	}
+
+	stoppedContainer := &DiskCleanRecommend{
+		Type:  DiskCleanStoppedContainer,
+		Title: fmt.Sprintf("停止超过 %d 天的容器", stoppedDays),
+		Items: make([]DiskCleanItem, 0),
+	}
+	deadline := time.Now().Add(-time.Hour * 24 * time.Duration(stoppedDays))
+	for _, item := range diskUsage.Containers {
+		if item.State != "exited" && item.State != "created" && item.State != "dead" {
+			continue
+		}
+		if self.containerStoppedAt(item).After(deadline) {
+			continue
+		}
+		name := item.ID
+		if len(item.Names) > 0 {
+			name = strings.TrimPrefix(item.Names[0], "/")
+		}
+		stoppedContainer.Size += item.SizeRw
+		stoppedContainer.Items = append(stoppedContainer.Items, DiskCleanItem{
+			Id:   item.ID,
+			Name: name,
+			Size: item.SizeRw,
+		})
+	}
+
+	unusedVolume := &DiskCleanRecommend{
+		Type:  DiskCleanUnusedVolume,
+		Title: "未被容器使用的存储卷",
+		Items: make([]DiskCleanItem, 0),
+	}
+	for _, item := range diskUsage.Volumes {
+		if item.UsageData == nil || item.UsageData.RefCount != 0 {
+			continue
+		}
+		size := item.UsageData.Size
+		if size < 0 {
+			size = 0
+		}
+		unusedVolume.Size += size
+		unusedVolume.Items = append(unusedVolume.Items, DiskCleanItem{
+			Id:   item.Name,
+			Name: item.Name,
+			Size: size,
+		})
+	}
+
+	buildCache := &DiskCleanRecommend{
+		Type:  DiskCleanBuildCache,
+		Title: "构建缓存",
+		Items: make([]DiskCleanItem, 0),
+	}
+	for _, item := range diskUsage.BuildCache {
+		if item.InUse || item.Shared {
+			continue
+		}
+		buildCache.Size += item.Size
+		buildCache.Items = append(buildCache.Items, DiskCleanItem{
+			Id:   item.ID,
+			Name: item.Description,
+			Size: item.Size,
+		})
+	}
+
+	return []*DiskCleanRecommend{
+		danglingImage, stoppedContainer, unusedVolume, buildCache,
+	}
+}
+
+// Match 重新计算清理建议，只保留用户确认过并且仍然符合条件的项目
+func (self DiskUsage) Match(cleanType string, stoppedDays int, idList []string) (*DiskCleanRecommend, error) {
+	diskUsage, err := self.Refresh()
+	if err != nil {
+		return nil, err
+	}
+	var recommend *DiskCleanRecommend
+	for _, item := range self.GetRecommend(diskUsage, stoppedDays) {
+		if item.Type == cleanType {
+			recommend = item
+			break
+		}
+	}
+	if recommend == nil {
+		return nil, errors.New("不支持的清理类型")
+	}
+	result := &DiskCleanRecommend{
+		Type:  recommend.Type,
+		Title: recommend.Title,
+		Items: make([]DiskCleanItem, 0),
+	}
+	for _, item := range recommend.Items {
+		if !function.InArray(idList, item.Id) {
+			continue
+		}
+		result.Size += item.Size
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
+
+// Remove 清理镜像、存储卷及构建缓存，容器需要通过删除容器的流程处理面板中的关联数据
+func (self DiskUsage) Remove(cleanType string, item DiskCleanItem) error {
+	switch cleanType {
+	case DiskCleanDanglingImage:
+		_, err := docker.Sdk.Client.ImageRemove(docker.Sdk.Ctx, item.Id, image.RemoveOptions{
+			PruneChildren: true,
+		})
+		return err
+	case DiskCleanUnusedVolume:
+		return docker.Sdk.Client.VolumeRemove(docker.Sdk.Ctx, item.Id, false)
+	case DiskCleanBuildCache:
+		_, err := docker.Sdk.Client.BuildCachePrune(docker.Sdk.Ctx, types.BuildCachePruneOptions{
+			All:     true,
+			Filters: filters.NewArgs(filters.Arg("id", item.Id)),
+		})
+		return err
+	}
+	return errors.New("不支持的清理类型")
+}
+
+func (self DiskUsage) snapshot(diskUsage *types.DiskUsage) *entity.DiskUsage {
+	row := &entity.DiskUsage{
+		Image: diskUsage.LayersSize,
+	}
+	for _, item := range diskUsage.Containers {
+		row.Container += item.SizeRw
+	}
+	for _, item := range diskUsage.Volumes {
+		if item.UsageData != nil && item.UsageData.Size > 0 {
+			row.Volume += item.UsageData.Size
+		}
+	}
+	for _, item := range diskUsage.BuildCache {
+		if !item.Shared {
+			row.BuildCache += item.Size
+		}
+	}
+	return row
+}
+
+// isDangling 与 dangling=true 过滤条件一致，没有标签也没有摘要的镜像
+// 通过摘要拉取的镜像没有标签，但仍然可以被引用
+func (self DiskUsage) isDangling(item *image.Summary) bool {
+	for _, tag := range item.RepoTags {
+		if tag != "<none>:<none>" {
+			return false
+		}
+	}
+	for _, digest := range item.RepoDigests {
+		if digest != "<none>@<none>" {
+			return false
+		}
+	}
+	return true
+}
+
+// 容器停止的时间，未运行过的容器使用创建时间
+func (self DiskUsage) containerStoppedAt(item *types.Container) time.Time {
+	createdAt := time.Unix(item.Created, 0)
+	info, err := docker.Sdk.Client.ContainerInspect(docker.Sdk.Ctx, item.ID)
+	if err != nil || info.State == nil {
+		return createdAt
+	}
+	finishedAt, err := time.Parse(time.RFC3339Nano, info.State.FinishedAt)
+	if err != nil || finishedAt.IsZero() || finishedAt.Year() <= 1 {
+		return createdAt
+	}
+	return finishedAt
+}
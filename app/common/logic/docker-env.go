@@ -3,6 +3,7 @@ package logic
 import (
 	"github.com/donknap/dpanel/common/accessor"
 	"github.com/donknap/dpanel/common/entity"
+	"github.com/donknap/dpanel/common/service/docker"
 	"golang.org/x/exp/maps"
 )
 
@@ -27,3 +28,16 @@ func (self DockerEnv) UpdateEnv(data *accessor.DockerClientResult) {
 	_ = Setting{}.Save(setting)
 	return
 }
+
+// GetCurrentName 返回当前连接的 docker 环境名称
+func (self DockerEnv) GetCurrentName() string {
+	setting, err := Setting{}.GetValue(SettingGroupSetting, SettingGroupSettingDocker)
+	if err == nil && setting.Value != nil {
+		for _, item := range setting.Value.Docker {
+			if item.Address == docker.Sdk.Client.DaemonHost() {
+				return item.Name
+			}
+		}
+	}
+	return "local"
+}
@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"github.com/donknap/dpanel/common/service/docker"
+	"github.com/donknap/dpanel/common/service/procfs"
+	"log/slog"
+	"time"
+)
+
+type Host struct {
+}
+
+func (self Host) GetMetrics() (*procfs.Metrics, error) {
+	dockerRootDir := ""
+	info, err := docker.Sdk.Client.Info(docker.Sdk.Ctx)
+	if err == nil {
+		dockerRootDir = info.DockerRootDir
+	}
+	return procfs.Collector.Collect(dockerRootDir)
+}
+
+// MonitorLoop 有通知连接时定时采集主机指标并推送
+func (self Host) MonitorLoop() {
+	ticker := time.NewTicker(time.Second * 5)
+	defer ticker.Stop()
+	for range ticker.C {
+		if noticeClientTotal() == 0 {
+			continue
+		}
+		metrics, err := self.GetMetrics()
+		if err != nil {
+			slog.Debug("host", "metrics", err.Error())
+			continue
+		}
+		select {
+		case procfs.QueueHostMetricsMessage <- metrics:
+		default:
+		}
+	}
+}
@@ -8,10 +8,13 @@ import (
 
 // 全局配置
 var (
-	SettingGroupSetting          = "setting"
-	SettingGroupSettingServer    = "server" // 服务器
-	SettingGroupSettingDocker    = "docker" // docker env
-	SettingGroupSettingDiskUsage = "diskUsage"
+	SettingGroupSetting              = "setting"
+	SettingGroupSettingServer        = "server" // 服务器
+	SettingGroupSettingDocker        = "docker" // docker env
+	SettingGroupSettingDiskUsage     = "diskUsage"
+	SettingGroupSettingLogIndex      = "logIndex"      // 容器日志索引
+	SettingGroupSettingLogArchive    = "logArchive"    // 容器日志归档
+	SettingGroupSettingConsoleRecord = "consoleRecord" // 终端录像
 )
 
 // 用户相关数据
@@ -1,8 +1,10 @@
 package logic
 
 import (
+	"errors"
 	"github.com/donknap/dpanel/common/function"
 	"github.com/donknap/dpanel/common/service/docker"
+	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/we7coreteam/w7-rangine-go/v2/pkg/support/facade"
 )
@@ -21,6 +23,40 @@ func (self User) GetJwtSecret() []byte {
 	return []byte(docker.BuilderAuthor + facade.GetConfig().GetString("app.name"))
 }
 
+// ParseToken 校验 jwt 并返回其中的用户信息
+func (self User) ParseToken(authToken string) (*UserInfo, error) {
+	myUserInfo := &UserInfo{}
+	token, err := jwt.ParseWithClaims(authToken, myUserInfo, func(t *jwt.Token) (interface{}, error) {
+		return self.GetJwtSecret(), nil
+	}, jwt.WithValidMethods([]string{"HS256"}))
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("请先登录")
+	}
+	if _, err = (Setting{}).GetValueById(myUserInfo.UserId); err != nil {
+		return nil, err
+	}
+	return myUserInfo, nil
+}
+
+// GetUsernameByContext 获取当前请求的用户名，用于记录操作人
+// ws 路由不经过登录中间件，从 token 参数中解析当前用户
+func (self User) GetUsernameByContext(http *gin.Context) string {
+	if data, exists := http.Get("userInfo"); exists {
+		if userInfo, ok := data.(UserInfo); ok {
+			return userInfo.Username
+		}
+	}
+	if token := http.Query("token"); token != "" {
+		if userInfo, err := self.ParseToken(token); err == nil {
+			return userInfo.Username
+		}
+	}
+	return ""
+}
+
 func (self User) GetMd5Password(password string, key string) string {
 	return function.GetMd5(password + key)
 }
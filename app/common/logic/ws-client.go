@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"github.com/donknap/dpanel/common/service/docker"
 	"github.com/donknap/dpanel/common/service/notice"
+	"github.com/donknap/dpanel/common/service/procfs"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"log/slog"
@@ -21,6 +22,7 @@ var (
 )
 
 type ClientOptions struct {
+	Notice         bool // 通知连接，接收主机指标等面板推送的消息
 	CloseHandler   func()
 	MessageHandler map[string]func(message []byte)
 }
@@ -43,6 +45,7 @@ func NewClientConn(ctx *gin.Context, options *ClientOptions) (*Client, error) {
 		CtxContext:    ctxWs,
 		CtxCancelFunc: ctxWsCancel,
 		closeHandler:  options.CloseHandler,
+		notice:        options.Notice,
 	}
 	client.SendMessageQueue = make(chan string)
 	client.readMessageHandler = options.MessageHandler
@@ -62,6 +65,7 @@ type Client struct {
 	SendMessageQueue   chan string
 	readMessageHandler map[string]func(message []byte)
 	closeHandler       func()
+	notice             bool
 }
 
 type recvMessage struct {
@@ -153,6 +157,18 @@ func (self *Client) SendMessage() {
 				Data: message,
 			}
 			self.sendMessage(data)
+		case message := <-docker.QueueContainerBatchMessage:
+			data := &respMessage{
+				Type: "containerBatch",
+				Data: message,
+			}
+			self.sendMessage(data)
+		case message := <-procfs.QueueHostMetricsMessage:
+			data := &respMessage{
+				Type: "hostMetrics",
+				Data: message,
+			}
+			self.sendNoticeMessage(data)
 		}
 	}
 }
@@ -169,6 +185,61 @@ func (self *Client) sendMessage(message *respMessage) {
 	lock.Unlock()
 }
 
+// 只发送给通知连接，终端及日志等连接直接输出内容，不能混入其它消息
+func (self *Client) sendNoticeMessage(message *respMessage) {
+	lock.Lock()
+	for _, client := range wsCollect {
+		if !client.notice {
+			continue
+		}
+		err := client.Conn.WriteMessage(websocket.TextMessage, message.ToJson())
+		if err != nil {
+			slog.Error("ws send message error", "fd", self.Id, "error", err.Error())
+		}
+	}
+	lock.Unlock()
+}
+
+func noticeClientTotal() int {
+	lock.RLock()
+	defer lock.RUnlock()
+	total := 0
+	for _, client := range wsCollect {
+		if client.notice {
+			total++
+		}
+	}
+	return total
+}
+
+// 只给当前连接发送消息，与广播共用锁避免并发写 ws
+func (self *Client) sendMessageToSelf(message *respMessage) {
+	lock.Lock()
+	err := self.Conn.WriteMessage(websocket.TextMessage, message.ToJson())
+	if err != nil {
+		slog.Error("ws send message error", "fd", self.Id, "error", err.Error())
+	}
+	lock.Unlock()
+}
+
+// WriteMessage 只给当前连接发送指定类型的消息
+func (self *Client) WriteMessage(messageType string, data interface{}) {
+	self.sendMessageToSelf(&respMessage{
+		Type: messageType,
+		Data: data,
+	})
+}
+
+// WriteRawMessage 只给当前连接发送原始文本，用于终端输出
+func (self *Client) WriteRawMessage(data []byte) {
+	lock.Lock()
+	err := self.Conn.WriteMessage(websocket.TextMessage, data)
+	if err != nil {
+		slog.Error("ws send message error", "fd", self.Id, "error", err.Error())
+	}
+	lock.Unlock()
+}
+
 func (self *Client) Close() error {
 	if self.closeHandler != nil {
 		self.closeHandler()
@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/donknap/dpanel/common/service/docker"
+	"log/slog"
+	"sync"
+)
+
+const (
+	containerStatActionSubscribe   = "subscribe"
+	containerStatActionUnsubscribe = "unsubscribe"
+)
+
+type containerStatMessage struct {
+	Type    string `json:"type"`
+	Content struct {
+		Action string   `json:"action"`
+		Id     []string `json:"id"`
+	} `json:"content"`
+}
+
+func NewContainerStatSubscriber() *ContainerStatSubscriber {
+	return &ContainerStatSubscriber{
+		stream: make(map[string]*containerStatStream),
+	}
+}
+
+// ContainerStatSubscriber 一个 ws 连接上订阅的容器状态，每个容器对应一个 docker stats 流
+type ContainerStatSubscriber struct {
+	client *Client
+	lock   sync.Mutex
+	stream map[string]*containerStatStream
+}
+
+type containerStatStream struct {
+	cancel context.CancelFunc
+}
+
+func (self *ContainerStatSubscriber) WithClient(client *Client) *ContainerStatSubscriber {
+	self.client = client
+	return self
+}
+
+func (self *ContainerStatSubscriber) MessageHandler(message []byte) {
+	cmd := containerStatMessage{}
+	err := json.Unmarshal(message, &cmd)
+	if err != nil {
+		return
+	}
+	switch cmd.Content.Action {
+	case containerStatActionSubscribe:
+		self.Subscribe(cmd.Content.Id...)
+	case containerStatActionUnsubscribe:
+		self.Unsubscribe(cmd.Content.Id...)
+	}
+}
+
+func (self *ContainerStatSubscriber) Subscribe(md5 ...string) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	for _, id := range md5 {
+		if _, ok := self.stream[id]; ok {
+			continue
+		}
+		ctx, cancelFunc := context.WithCancel(self.client.CtxContext)
+		statsChan, err := docker.Sdk.ContainerStatsStream(ctx, id)
+		if err != nil {
+			cancelFunc()
+			slog.Debug("container stats subscribe", "id", id, "error", err.Error())
+			continue
+		}
+		stream := &containerStatStream{
+			cancel: cancelFunc,
+		}
+		self.stream[id] = stream
+		go func(id string) {
+			for item := range statsChan {
+				self.client.sendMessageToSelf(&respMessage{
+					Type: "containerStats",
+					Data: item,
+				})
+			}
+			// 容器停止后 docker 会结束 stats 流，这里同步清理订阅
+			self.lock.Lock()
+			stream.cancel()
+			// 取消后重新订阅的是新的流，不能删除
+			if self.stream[id] == stream {
+				delete(self.stream, id)
+			}
+			self.lock.Unlock()
+		}(id)
+	}
+}
+
+func (self *ContainerStatSubscriber) Unsubscribe(md5 ...string) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	for _, id := range md5 {
+		if stream, ok := self.stream[id]; ok {
+			stream.cancel()
+			delete(self.stream, id)
+		}
+	}
+}
+
+func (self *ContainerStatSubscriber) Close() {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	for id, stream := range self.stream {
+		stream.cancel()
+		delete(self.stream, id)
+	}
+}
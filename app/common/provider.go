@@ -49,12 +49,23 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 		cors.POST("/common/home/info", controller.Home{}.Info)
 		cors.POST("/common/home/upgrade-script", controller.Home{}.UpgradeScript)
 		cors.POST("/common/home/get-stat-list", controller.Home{}.GetStatList)
+		cors.POST("/common/home/get-host-info", controller.Home{}.GetHostInfo)
+		cors.POST("/common/home/get-disk-usage-history", controller.Home{}.GetDiskUsageHistory)
+		cors.POST("/common/home/get-clean-recommend", controller.Home{}.GetCleanRecommend)
+		cors.POST("/common/home/get-console-session-list", controller.Home{}.GetConsoleSessionList)
 
 		// 环境管理
 		cors.POST("/common/env/get-list", controller.Env{}.GetList)
 		cors.POST("/common/env/create", controller.Env{}.Create)
 		cors.POST("/common/env/switch", controller.Env{}.Switch)
 		cors.POST("/common/env/delete", controller.Env{}.Delete)
+
+		// 终端录像
+		cors.POST("/common/console-record/get-list", controller.ConsoleRecord{}.GetList)
+		cors.POST("/common/console-record/play", controller.ConsoleRecord{}.Play)
+		cors.POST("/common/console-record/delete", controller.ConsoleRecord{}.Delete)
+		cors.POST("/common/console-record/get-setting", controller.ConsoleRecord{}.GetSetting)
+		cors.POST("/common/console-record/save-setting", controller.ConsoleRecord{}.SaveSetting)
 	})
 
 	httpServer.RegisterRouters(func(engine *gin.Engine) {
@@ -62,6 +73,7 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 
 		wsCors.GET("/common/notice", controller.Home{}.WsNotice)
 		wsCors.GET("/common/console/:id", controller.Home{}.WsConsole)
+		wsCors.GET("/common/console/join/:sessionId", controller.Home{}.WsConsoleJoin)
 	})
 
 	// 当前如果有连接，则添加一条docker环境数据
@@ -75,4 +87,6 @@ func (provider *Provider) Register(httpServer *http_server.Server) {
 		})
 		go logic.EventLogic{}.MonitorLoop()
 	}
+	go logic.Host{}.MonitorLoop()
+	go logic.DiskUsage{}.MonitorLoop()
 }
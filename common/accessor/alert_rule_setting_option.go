@@ -0,0 +1,33 @@
+package accessor
+
+import "time"
+
+type AlertSelectorItem struct {
+	Type  string `json:"type"`  // name label compose
+	Value string `json:"value"` // name 支持通配符，label 格式为 key 或 key=value
+}
+
+type AlertSilenceItem struct {
+	StartAt time.Time `json:"startAt"`
+	EndAt   time.Time `json:"endAt"`
+}
+
+type AlertRuleSettingOption struct {
+	Metric     string             `json:"metric"`     // cpu memory
+	Comparator string             `json:"comparator"` // gt gte lt lte
+	Threshold  float64            `json:"threshold"`
+	Duration   int                `json:"duration"` // 持续多少秒后触发
+	Selector   AlertSelectorItem  `json:"selector"`
+	Webhook    []string           `json:"webhook,omitempty"`
+	Silence    []AlertSilenceItem `json:"silence,omitempty"`
+}
+
+// IsSilenced 判断某个时间是否处于静默时段内
+func (self AlertRuleSettingOption) IsSilenced(t time.Time) bool {
+	for _, item := range self.Silence {
+		if !t.Before(item.StartAt) && t.Before(item.EndAt) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package accessor
+
+type CronSettingOption struct {
+	Type              string   `json:"type"`                    // 任务类型
+	ContainerName     string   `json:"containerName,omitempty"` // 容器操作及执行命令时使用
+	Command           string   `json:"command,omitempty"`
+	WorkDir           string   `json:"workDir,omitempty"`
+	ImageName         string   `json:"imageName,omitempty"`
+	PruneTarget       []string `json:"pruneTarget,omitempty"` // container image volume network buildCache
+	ComposeId         int32    `json:"composeId,omitempty"`
+	Timeout           int      `json:"timeout"`           // 超时秒数，0 为不限制
+	ConcurrencyPolicy string   `json:"concurrencyPolicy"` // skip 上次未结束时跳过，allow 允许同时运行
+	KeepLogs          int      `json:"keepLogs"`          // 保留的执行记录数量
+}
@@ -6,12 +6,15 @@ import (
 )
 
 type SettingValueOption struct {
-	Username       string                         `json:"username,omitempty"`
-	Password       string                         `json:"password,omitempty"`
-	ServerIp       string                         `json:"serverIp,omitempty"`
-	RequestTimeout int                            `json:"requestTimeout,omitempty"`
-	Docker         map[string]*DockerClientResult `json:"docker,omitempty"`
-	DiskUsage      DiskUsage                      `json:"diskUsage,omitempty"`
+	Username       string                           `json:"username,omitempty"`
+	Password       string                           `json:"password,omitempty"`
+	ServerIp       string                           `json:"serverIp,omitempty"`
+	RequestTimeout int                              `json:"requestTimeout,omitempty"`
+	Docker         map[string]*DockerClientResult   `json:"docker,omitempty"`
+	DiskUsage      DiskUsage                        `json:"diskUsage,omitempty"`
+	LogIndex       *LogIndexSetting                 `json:"logIndex,omitempty"`
+	LogArchive     *LogArchiveSetting               `json:"logArchive,omitempty"`
+	ConsoleRecord  map[string]*ConsoleRecordSetting `json:"consoleRecord,omitempty"` // 按 docker 环境名称配置
 }
 
 type DockerClientResult struct {
@@ -29,3 +32,22 @@ type DiskUsage struct {
 	Usage     types.DiskUsage `json:"usage,omitempty"`
 	UpdatedAt time.Time       `json:"updatedAt,omitempty"`
 }
+
+type LogIndexSetting struct {
+	Enable        bool   `json:"enable"`
+	Label         string `json:"label"`         // 只收集包含此标签的容器，格式为 key 或 key=value，为空时收集全部
+	RetentionDays int    `json:"retentionDays"` // 保留天数
+	MaxRows       int    `json:"maxRows"`       // 最多保留的行数
+}
+
+type LogArchiveSetting struct {
+	Disable       bool `json:"disable"`       // 默认开启，删除或重建容器前归档日志
+	RetentionDays int  `json:"retentionDays"` // 保留天数
+	MaxCount      int  `json:"maxCount"`      // 每个容器最多保留的归档数量
+}
+
+type ConsoleRecordSetting struct {
+	Enable        bool `json:"enable"`
+	RecordInput   bool `json:"recordInput"`   // 同时记录用户输入，可能包含密码等敏感信息
+	RetentionDays int  `json:"retentionDays"` // 保留天数，为 0 时不清理
+}
@@ -70,6 +70,27 @@ type ReplaceItem struct {
 	Target string `json:"target"`
 }
 
+type HealthcheckItem struct {
+	ShellType   string `json:"shellType"` // CMD CMD-SHELL NONE
+	Test        string `json:"test"`
+	Interval    int    `json:"interval"` // 秒
+	Timeout     int    `json:"timeout"`  // 秒
+	Retries     int    `json:"retries"`
+	StartPeriod int    `json:"startPeriod"` // 秒
+}
+
+type DeviceItem struct {
+	Host       string `json:"host"`
+	Dest       string `json:"dest"`
+	Permission string `json:"permission"` // rwm
+}
+
+type UlimitItem struct {
+	Name string `json:"name"`
+	Soft int64  `json:"soft"`
+	Hard int64  `json:"hard"`
+}
+
 type SiteEnvOption struct {
 	Name            string               `json:"name"`
 	Environment     []EnvItem            `json:"environment,omitempty"`
@@ -100,4 +121,16 @@ type SiteEnvOption struct {
 	IpV4            ContainerNetworkItem `json:"ipV4,omitempty"`
 	IpV6            ContainerNetworkItem `json:"ipV6,omitempty"`
 	Replace         []ReplaceItem        `json:"replace,omitempty"`
+	Healthcheck     *HealthcheckItem     `json:"healthcheck,omitempty"`
+	CapAdd          []string             `json:"capAdd,omitempty"`
+	CapDrop         []string             `json:"capDrop,omitempty"`
+	Devices         []DeviceItem         `json:"devices,omitempty"`
+	Ulimits         []UlimitItem         `json:"ulimits,omitempty"`
+	Sysctls         []EnvItem            `json:"sysctls,omitempty"`
+	SecurityOpt     []string             `json:"securityOpt,omitempty"`
+	Tmpfs           []EnvItem            `json:"tmpfs,omitempty"` // name 为容器内目录，value 为挂载参数
+	Init            bool                 `json:"init,omitempty"`
+	StopSignal      string               `json:"stopSignal,omitempty"`
+	StopTimeout     int                  `json:"stopTimeout,omitempty"` // 秒
+	Hostname        string               `json:"hostname,omitempty"`
 }
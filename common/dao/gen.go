@@ -16,74 +16,129 @@ import (
 )
 
 var (
-	Q          = new(Query)
-	Backup     *backup
-	Compose    *compose
-	Event      *event
-	Image      *image
-	Notice     *notice
-	Registry   *registry
-	Setting    *setting
-	Site       *site
-	SiteDomain *siteDomain
+	Q             = new(Query)
+	AlertRule     *alertRule
+	Backup        *backup
+	Compose       *compose
+	ConsoleRecord *consoleRecord
+	ContainerExec *containerExec
+	ContainerLog  *containerLog
+	ContainerStat *containerStat
+	Cron          *cron
+	CronLog       *cronLog
+	DiskUsage     *diskUsage
+	Event         *event
+	Image         *image
+	ImageUpdate   *imageUpdate
+	LogArchive    *logArchive
+	Notice        *notice
+	Registry      *registry
+	Setting       *setting
+	Site          *site
+	SiteDomain    *siteDomain
+	SiteRevision  *siteRevision
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	AlertRule = &Q.AlertRule
 	Backup = &Q.Backup
 	Compose = &Q.Compose
+	ConsoleRecord = &Q.ConsoleRecord
+	ContainerExec = &Q.ContainerExec
+	ContainerLog = &Q.ContainerLog
+	ContainerStat = &Q.ContainerStat
+	Cron = &Q.Cron
+	CronLog = &Q.CronLog
+	DiskUsage = &Q.DiskUsage
 	Event = &Q.Event
 	Image = &Q.Image
+	ImageUpdate = &Q.ImageUpdate
+	LogArchive = &Q.LogArchive
 	Notice = &Q.Notice
 	Registry = &Q.Registry
 	Setting = &Q.Setting
 	Site = &Q.Site
 	SiteDomain = &Q.SiteDomain
+	SiteRevision = &Q.SiteRevision
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:         db,
-		Backup:     newBackup(db, opts...),
-		Compose:    newCompose(db, opts...),
-		Event:      newEvent(db, opts...),
-		Image:      newImage(db, opts...),
-		Notice:     newNotice(db, opts...),
-		Registry:   newRegistry(db, opts...),
-		Setting:    newSetting(db, opts...),
-		Site:       newSite(db, opts...),
-		SiteDomain: newSiteDomain(db, opts...),
+		db:            db,
+		AlertRule:     newAlertRule(db, opts...),
+		Backup:        newBackup(db, opts...),
+		Compose:       newCompose(db, opts...),
+		ConsoleRecord: newConsoleRecord(db, opts...),
+		ContainerExec: newContainerExec(db, opts...),
+		ContainerLog:  newContainerLog(db, opts...),
+		ContainerStat: newContainerStat(db, opts...),
+		Cron:          newCron(db, opts...),
+		CronLog:       newCronLog(db, opts...),
+		DiskUsage:     newDiskUsage(db, opts...),
+		Event:         newEvent(db, opts...),
+		Image:         newImage(db, opts...),
+		ImageUpdate:   newImageUpdate(db, opts...),
+		LogArchive:    newLogArchive(db, opts...),
+		Notice:        newNotice(db, opts...),
+		Registry:      newRegistry(db, opts...),
+		Setting:       newSetting(db, opts...),
+		Site:          newSite(db, opts...),
+		SiteDomain:    newSiteDomain(db, opts...),
+		SiteRevision:  newSiteRevision(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	Backup     backup
-	Compose    compose
-	Event      event
-	Image      image
-	Notice     notice
-	Registry   registry
-	Setting    setting
-	Site       site
-	SiteDomain siteDomain
+	AlertRule     alertRule
+	Backup        backup
+	Compose       compose
+	ConsoleRecord consoleRecord
+	ContainerExec containerExec
+	ContainerLog  containerLog
+	ContainerStat containerStat
+	Cron          cron
+	CronLog       cronLog
+	DiskUsage     diskUsage
+	Event         event
+	Image         image
+	ImageUpdate   imageUpdate
+	LogArchive    logArchive
+	Notice        notice
+	Registry      registry
+	Setting       setting
+	Site          site
+	SiteDomain    siteDomain
+	SiteRevision  siteRevision
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:         db,
-		Backup:     q.Backup.clone(db),
-		Compose:    q.Compose.clone(db),
-		Event:      q.Event.clone(db),
-		Image:      q.Image.clone(db),
-		Notice:     q.Notice.clone(db),
-		Registry:   q.Registry.clone(db),
-		Setting:    q.Setting.clone(db),
-		Site:       q.Site.clone(db),
-		SiteDomain: q.SiteDomain.clone(db),
+		db:            db,
+		AlertRule:     q.AlertRule.clone(db),
+		Backup:        q.Backup.clone(db),
+		Compose:       q.Compose.clone(db),
+		ConsoleRecord: q.ConsoleRecord.clone(db),
+		ContainerExec: q.ContainerExec.clone(db),
+		ContainerLog:  q.ContainerLog.clone(db),
+		ContainerStat: q.ContainerStat.clone(db),
+		Cron:          q.Cron.clone(db),
+		CronLog:       q.CronLog.clone(db),
+		DiskUsage:     q.DiskUsage.clone(db),
+		Event:         q.Event.clone(db),
+		Image:         q.Image.clone(db),
+		ImageUpdate:   q.ImageUpdate.clone(db),
+		LogArchive:    q.LogArchive.clone(db),
+		Notice:        q.Notice.clone(db),
+		Registry:      q.Registry.clone(db),
+		Setting:       q.Setting.clone(db),
+		Site:          q.Site.clone(db),
+		SiteDomain:    q.SiteDomain.clone(db),
+		SiteRevision:  q.SiteRevision.clone(db),
 	}
 }
 
@@ -97,42 +152,75 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:         db,
-		Backup:     q.Backup.replaceDB(db),
-		Compose:    q.Compose.replaceDB(db),
-		Event:      q.Event.replaceDB(db),
-		Image:      q.Image.replaceDB(db),
-		Notice:     q.Notice.replaceDB(db),
-		Registry:   q.Registry.replaceDB(db),
-		Setting:    q.Setting.replaceDB(db),
-		Site:       q.Site.replaceDB(db),
-		SiteDomain: q.SiteDomain.replaceDB(db),
+		db:            db,
+		AlertRule:     q.AlertRule.replaceDB(db),
+		Backup:        q.Backup.replaceDB(db),
+		Compose:       q.Compose.replaceDB(db),
+		ConsoleRecord: q.ConsoleRecord.replaceDB(db),
+		ContainerExec: q.ContainerExec.replaceDB(db),
+		ContainerLog:  q.ContainerLog.replaceDB(db),
+		ContainerStat: q.ContainerStat.replaceDB(db),
+		Cron:          q.Cron.replaceDB(db),
+		CronLog:       q.CronLog.replaceDB(db),
+		DiskUsage:     q.DiskUsage.replaceDB(db),
+		Event:         q.Event.replaceDB(db),
+		Image:         q.Image.replaceDB(db),
+		ImageUpdate:   q.ImageUpdate.replaceDB(db),
+		LogArchive:    q.LogArchive.replaceDB(db),
+		Notice:        q.Notice.replaceDB(db),
+		Registry:      q.Registry.replaceDB(db),
+		Setting:       q.Setting.replaceDB(db),
+		Site:          q.Site.replaceDB(db),
+		SiteDomain:    q.SiteDomain.replaceDB(db),
+		SiteRevision:  q.SiteRevision.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	Backup     IBackupDo
-	Compose    IComposeDo
-	Event      IEventDo
-	Image      IImageDo
-	Notice     INoticeDo
-	Registry   IRegistryDo
-	Setting    ISettingDo
-	Site       ISiteDo
-	SiteDomain ISiteDomainDo
+	AlertRule     IAlertRuleDo
+	Backup        IBackupDo
+	Compose       IComposeDo
+	ConsoleRecord IConsoleRecordDo
+	ContainerExec IContainerExecDo
+	ContainerLog  IContainerLogDo
+	ContainerStat IContainerStatDo
+	Cron          ICronDo
+	CronLog       ICronLogDo
+	DiskUsage     IDiskUsageDo
+	Event         IEventDo
+	Image         IImageDo
+	ImageUpdate   IImageUpdateDo
+	LogArchive    ILogArchiveDo
+	Notice        INoticeDo
+	Registry      IRegistryDo
+	Setting       ISettingDo
+	Site          ISiteDo
+	SiteDomain    ISiteDomainDo
+	SiteRevision  ISiteRevisionDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		Backup:     q.Backup.WithContext(ctx),
-		Compose:    q.Compose.WithContext(ctx),
-		Event:      q.Event.WithContext(ctx),
-		Image:      q.Image.WithContext(ctx),
-		Notice:     q.Notice.WithContext(ctx),
-		Registry:   q.Registry.WithContext(ctx),
-		Setting:    q.Setting.WithContext(ctx),
-		Site:       q.Site.WithContext(ctx),
-		SiteDomain: q.SiteDomain.WithContext(ctx),
+		AlertRule:     q.AlertRule.WithContext(ctx),
+		Backup:        q.Backup.WithContext(ctx),
+		Compose:       q.Compose.WithContext(ctx),
+		ConsoleRecord: q.ConsoleRecord.WithContext(ctx),
+		ContainerExec: q.ContainerExec.WithContext(ctx),
+		ContainerLog:  q.ContainerLog.WithContext(ctx),
+		ContainerStat: q.ContainerStat.WithContext(ctx),
+		Cron:          q.Cron.WithContext(ctx),
+		CronLog:       q.CronLog.WithContext(ctx),
+		DiskUsage:     q.DiskUsage.WithContext(ctx),
+		Event:         q.Event.WithContext(ctx),
+		Image:         q.Image.WithContext(ctx),
+		ImageUpdate:   q.ImageUpdate.WithContext(ctx),
+		LogArchive:    q.LogArchive.WithContext(ctx),
+		Notice:        q.Notice.WithContext(ctx),
+		Registry:      q.Registry.WithContext(ctx),
+		Setting:       q.Setting.WithContext(ctx),
+		Site:          q.Site.WithContext(ctx),
+		SiteDomain:    q.SiteDomain.WithContext(ctx),
+		SiteRevision:  q.SiteRevision.WithContext(ctx),
 	}
 }
 
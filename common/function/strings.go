@@ -1,33 +1,98 @@
 package function
 
 import (
+	"fmt"
 	"strings"
 )
 
+// CommandSplit 按空白字符拆分命令，单引号、双引号中的空白字符不拆分
+// 引号外可以使用 \ 转义空格、引号及 \ 本身，双引号中可以转义双引号及 \，单引号中的内容原样保留
+// 引号外及双引号中 \ 加换行表示命令换行
 func CommandSplit(cmd string) []string {
+	result, _ := commandSplit(cmd)
+	return result
+}
+
+// CommandSplitStrict 与 CommandSplit 相同，引号没有闭合时返回错误
+func CommandSplitStrict(cmd string) ([]string, error) {
+	result, quote := commandSplit(cmd)
+	if quote != 0 {
+		return nil, fmt.Errorf("命令中的引号 %c 没有闭合", quote)
+	}
+	return result, nil
+}
+
+// 返回拆分后的参数及没有闭合的引号
+func commandSplit(cmd string) ([]string, rune) {
 	result := make([]string, 0)
 
-	field := ""
-	ignoreSpace := false
+	field := strings.Builder{}
+	hasField := false
+	quote := rune(0)
+	escape := false
 
-	for _, s := range strings.Split(cmd, "") {
-		if s == " " && !ignoreSpace {
-			result = append(result, field)
-			field = ""
-			continue
+	for _, s := range cmd {
+		if escape {
+			// \ 换行在引号外及双引号中表示续行，兼容 \r\n
+			if s == '\r' {
+				continue
+			}
+			escape = false
+			if s == '\n' {
+				continue
+			}
+			hasField = true
+			if strings.ContainsRune(commandEscapable(quote), s) {
+				field.WriteRune(s)
+				continue
+			}
+			field.WriteRune('\\')
 		}
-
-		if s == "\"" || s == "'" {
-			ignoreSpace = !ignoreSpace
-			continue
+		switch {
+		case s == '\\' && quote != '\'':
+			escape = true
+		case quote != 0 && s == quote:
+			quote = 0
+		case quote == 0 && (s == '"' || s == '\''):
+			quote = s
+			hasField = true
+		case quote == 0 && (s == ' ' || s == '\t' || s == '\n' || s == '\r'):
+			if hasField {
+				result = append(result, field.String())
+				field.Reset()
+				hasField = false
+			}
+		default:
+			field.WriteRune(s)
+			hasField = true
 		}
-
-		field += s
 	}
+	if escape {
+		field.WriteRune('\\')
+		hasField = true
+	}
+	if hasField {
+		result = append(result, field.String())
+	}
+
+	return result, quote
+}
 
-	if field != "" {
-		result = append(result, field)
+func commandEscapable(quote rune) string {
+	if quote == '"' {
+		return "\"\\"
 	}
+	return "\"'\\ \t"
+}
 
-	return result
+// CommandJoin 与 CommandSplit 相反，包含空格、引号或 \ 的参数使用双引号包裹并转义后拼接
+func CommandJoin(cmd []string) string {
+	result := make([]string, 0, len(cmd))
+	for _, item := range cmd {
+		if item == "" || strings.ContainsAny(item, " \t\r\n\"'\\") {
+			item = "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(item) + "\""
+		}
+		result = append(result, item)
+	}
+	return strings.Join(result, " ")
 }
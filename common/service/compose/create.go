@@ -10,6 +10,7 @@ import (
 	"github.com/donknap/dpanel/common/function"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func WithYamlPath(path string) cli.ProjectOptionsFn {
@@ -125,11 +126,17 @@ func NewComposeBySiteEnv(options ...accessor.SiteEnvOption) (*Wrapper, error) {
 		}
 
 		for _, item := range siteOption.Ports {
-			target, _ := strconv.Atoi(item.Dest)
+			// 容器中的端口可能带有协议，例如 80/udp
+			dest, protocol, _ := strings.Cut(item.Dest, "/")
+			if item.Protocol != "" {
+				protocol = item.Protocol
+			}
+			target, _ := strconv.Atoi(dest)
 			service.Ports = append(service.Ports, types.ServicePortConfig{
 				HostIP:    item.HostIp,
 				Target:    uint32(target),
 				Published: item.Host,
+				Protocol:  protocol,
 			})
 		}
 
@@ -228,6 +235,91 @@ func NewComposeBySiteEnv(options ...accessor.SiteEnvOption) (*Wrapper, error) {
 			}
 		}
 
+		if siteOption.Healthcheck != nil && siteOption.Healthcheck.Test != "" {
+			healthcheck := &types.HealthCheckConfig{}
+			switch siteOption.Healthcheck.ShellType {
+			case "CMD":
+				healthcheck.Test = append(types.HealthCheckTest{"CMD"}, function.CommandSplit(siteOption.Healthcheck.Test)...)
+			case "NONE":
+				healthcheck.Disable = true
+			default:
+				healthcheck.Test = types.HealthCheckTest{"CMD-SHELL", siteOption.Healthcheck.Test}
+			}
+			if siteOption.Healthcheck.Interval > 0 {
+				healthcheck.Interval = durationPtr(siteOption.Healthcheck.Interval)
+			}
+			if siteOption.Healthcheck.Timeout > 0 {
+				healthcheck.Timeout = durationPtr(siteOption.Healthcheck.Timeout)
+			}
+			if siteOption.Healthcheck.StartPeriod > 0 {
+				healthcheck.StartPeriod = durationPtr(siteOption.Healthcheck.StartPeriod)
+			}
+			if siteOption.Healthcheck.Retries > 0 {
+				retries := uint64(siteOption.Healthcheck.Retries)
+				healthcheck.Retries = &retries
+			}
+			service.HealthCheck = healthcheck
+		}
+
+		service.CapAdd = siteOption.CapAdd
+		service.CapDrop = siteOption.CapDrop
+		service.SecurityOpt = siteOption.SecurityOpt
+
+		for _, item := range siteOption.Devices {
+			if item.Host == "" {
+				continue
+			}
+			device := types.DeviceMapping{
+				Source:      item.Host,
+				Target:      item.Dest,
+				Permissions: item.Permission,
+			}
+			if device.Target == "" {
+				device.Target = device.Source
+			}
+			service.Devices = append(service.Devices, device)
+		}
+
+		if !function.IsEmptyArray(siteOption.Ulimits) {
+			service.Ulimits = make(map[string]*types.UlimitsConfig)
+			for _, item := range siteOption.Ulimits {
+				if item.Soft == item.Hard {
+					service.Ulimits[item.Name] = &types.UlimitsConfig{
+						Single: int(item.Soft),
+					}
+				} else {
+					service.Ulimits[item.Name] = &types.UlimitsConfig{
+						Soft: int(item.Soft),
+						Hard: int(item.Hard),
+					}
+				}
+			}
+		}
+
+		if !function.IsEmptyArray(siteOption.Sysctls) {
+			service.Sysctls = make(types.Mapping)
+			for _, item := range siteOption.Sysctls {
+				service.Sysctls[item.Name] = item.Value
+			}
+		}
+
+		for _, item := range siteOption.Tmpfs {
+			if item.Value != "" {
+				service.Tmpfs = append(service.Tmpfs, fmt.Sprintf("%s:%s", item.Name, item.Value))
+			} else {
+				service.Tmpfs = append(service.Tmpfs, item.Name)
+			}
+		}
+
+		if siteOption.Init {
+			service.Init = function.PtrBool(true)
+		}
+		service.StopSignal = siteOption.StopSignal
+		if siteOption.StopTimeout > 0 {
+			service.StopGracePeriod = durationPtr(siteOption.StopTimeout)
+		}
+		service.Hostname = siteOption.Hostname
+
 		service.Extensions = map[string]any{
 			ExtensionServiceName: extService,
 		}
@@ -269,3 +361,8 @@ func NewComposeBySiteEnv(options ...accessor.SiteEnvOption) (*Wrapper, error) {
 		Project: &project,
 	}, nil
 }
+
+func durationPtr(second int) *types.Duration {
+	duration := types.Duration(time.Duration(second) * time.Second)
+	return &duration
+}
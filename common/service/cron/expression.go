@@ -0,0 +1,256 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type bounds struct {
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	secondBounds = bounds{min: 0, max: 59}
+	minuteBounds = bounds{min: 0, max: 59}
+	hourBounds   = bounds{min: 0, max: 23}
+	domBounds    = bounds{min: 1, max: 31}
+	monthBounds  = bounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 0 与 7 都表示周日
+	dowBounds = bounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	descriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// 最多向后查找的年数，避免 2 月 30 日这样永远不会触发的表达式死循环
+const maxSearchYear = 5
+
+type Schedule struct {
+	second  uint64
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+	every   time.Duration
+}
+
+// Parse 解析 cron 表达式
+// 支持标准的 5 位格式（分 时 日 月 周）、带秒的 6 位格式、@daily 等描述符以及 @every 1h30m
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty cron expression")
+	}
+	if strings.HasPrefix(expr, "@every ") {
+		duration, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %s: %w", expr, err)
+		}
+		if duration < time.Second {
+			return nil, fmt.Errorf("invalid cron expression %s: interval must be at least 1s", expr)
+		}
+		return &Schedule{every: duration}, nil
+	}
+	if value, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = value
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression %s: expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	schedule := &Schedule{}
+	var err error
+	list := []struct {
+		target *uint64
+		bounds bounds
+	}{
+		{&schedule.second, secondBounds},
+		{&schedule.minute, minuteBounds},
+		{&schedule.hour, hourBounds},
+		{&schedule.dom, domBounds},
+		{&schedule.month, monthBounds},
+		{&schedule.dow, dowBounds},
+	}
+	for i, item := range list {
+		if *item.target, err = parseField(fields[i], item.bounds); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %s: %w", expr, err)
+		}
+	}
+	// 周日统一使用 0
+	if schedule.dow&(1<<7) > 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+	schedule.domStar = isStar(fields[3])
+	schedule.dowStar = isStar(fields[5])
+	return schedule, nil
+}
+
+// Next 返回 t 之后的下一次执行时间，没有符合条件的时间时返回零值
+func (self *Schedule) Next(t time.Time) time.Time {
+	if self.every > 0 {
+		return t.Truncate(time.Second).Add(self.every)
+	}
+	loc := t.Location()
+	t = t.Truncate(time.Second).Add(time.Second)
+	yearLimit := t.Year() + maxSearchYear
+	// 高位字段变化时低位字段需要从最小值开始
+	added := false
+
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+	for !has(self.month, int(t.Month())) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+	for !self.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 0, 1)
+		if t.Day() == 1 {
+			goto WRAP
+		}
+	}
+	for !has(self.hour, t.Hour()) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto WRAP
+		}
+	}
+	for !has(self.minute, t.Minute()) {
+		if !added {
+			added = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto WRAP
+		}
+	}
+	for !has(self.second, t.Second()) {
+		if !added {
+			added = true
+		}
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto WRAP
+		}
+	}
+	return t
+}
+
+// 日与周同时指定时满足其一即可，与 crontab 的行为一致
+func (self *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(self.dom, t.Day())
+	dowMatch := has(self.dow, int(t.Weekday()))
+	if self.domStar || self.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var result uint64
+	for _, part := range strings.Split(field, ",") {
+		bits, err := parseRange(part, b)
+		if err != nil {
+			return 0, err
+		}
+		result |= bits
+	}
+	return result, nil
+}
+
+func parseRange(expr string, b bounds) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(expr, "/")
+	start, end := b.min, b.max
+	step := 1
+	var err error
+
+	if rangeExpr != "*" && rangeExpr != "?" {
+		lowExpr, highExpr, hasRange := strings.Cut(rangeExpr, "-")
+		if start, err = parseValue(lowExpr, b); err != nil {
+			return 0, err
+		}
+		if hasRange {
+			if end, err = parseValue(highExpr, b); err != nil {
+				return 0, err
+			}
+		} else if !hasStep {
+			end = start
+		}
+	}
+	if hasStep {
+		if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step %s", expr)
+		}
+	}
+	if start > end {
+		return 0, fmt.Errorf("invalid range %s", expr)
+	}
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+	return bits, nil
+}
+
+func parseValue(expr string, b bounds) (int, error) {
+	if value, ok := b.names[strings.ToLower(expr)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %s", expr)
+	}
+	if value < b.min || value > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", value, b.min, b.max)
+	}
+	return value, nil
+}
+
+func isStar(field string) bool {
+	return field == "*" || field == "?" || strings.HasPrefix(field, "*/")
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) > 0
+}
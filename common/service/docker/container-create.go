@@ -7,12 +7,14 @@ import (
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 	"github.com/donknap/dpanel/common/function"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"io"
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 )
 
 type ContainerCreateBuilder struct {
@@ -237,6 +239,83 @@ func (self *ContainerCreateBuilder) WithExtraHosts(name, value string) {
 	self.hostConfig.ExtraHosts = append(self.hostConfig.ExtraHosts, fmt.Sprintf("%s:%s", name, value))
 }
 
+func (self *ContainerCreateBuilder) WithHealthcheck(test []string, interval, timeout, startPeriod time.Duration, retries int) {
+	self.containerConfig.Healthcheck = &container.HealthConfig{
+		Test:        test,
+		Interval:    interval,
+		Timeout:     timeout,
+		StartPeriod: startPeriod,
+		Retries:     retries,
+	}
+}
+
+func (self *ContainerCreateBuilder) WithCapAdd(cap ...string) {
+	self.hostConfig.CapAdd = append(self.hostConfig.CapAdd, cap...)
+}
+
+func (self *ContainerCreateBuilder) WithCapDrop(cap ...string) {
+	self.hostConfig.CapDrop = append(self.hostConfig.CapDrop, cap...)
+}
+
+func (self *ContainerCreateBuilder) WithDevice(host, dest, permission string) {
+	if permission == "" {
+		permission = "rwm"
+	}
+	if dest == "" {
+		dest = host
+	}
+	self.hostConfig.Devices = append(self.hostConfig.Devices, container.DeviceMapping{
+		PathOnHost:        host,
+		PathInContainer:   dest,
+		CgroupPermissions: permission,
+	})
+}
+
+func (self *ContainerCreateBuilder) WithUlimit(name string, soft, hard int64) {
+	if hard < soft {
+		hard = soft
+	}
+	self.hostConfig.Ulimits = append(self.hostConfig.Ulimits, &units.Ulimit{
+		Name: name,
+		Soft: soft,
+		Hard: hard,
+	})
+}
+
+func (self *ContainerCreateBuilder) WithSysctl(name, value string) {
+	if self.hostConfig.Sysctls == nil {
+		self.hostConfig.Sysctls = make(map[string]string)
+	}
+	self.hostConfig.Sysctls[name] = value
+}
+
+func (self *ContainerCreateBuilder) WithSecurityOpt(opt ...string) {
+	self.hostConfig.SecurityOpt = append(self.hostConfig.SecurityOpt, opt...)
+}
+
+func (self *ContainerCreateBuilder) WithTmpfs(path, option string) {
+	if self.hostConfig.Tmpfs == nil {
+		self.hostConfig.Tmpfs = make(map[string]string)
+	}
+	self.hostConfig.Tmpfs[path] = option
+}
+
+func (self *ContainerCreateBuilder) WithInit() {
+	self.hostConfig.Init = function.PtrBool(true)
+}
+
+func (self *ContainerCreateBuilder) WithStopSignal(signal string) {
+	self.containerConfig.StopSignal = signal
+}
+
+func (self *ContainerCreateBuilder) WithStopTimeout(second int) {
+	self.containerConfig.StopTimeout = &second
+}
+
+func (self *ContainerCreateBuilder) WithHostname(name string) {
+	self.containerConfig.Hostname = name
+}
+
 func (self *ContainerCreateBuilder) CreateOwnerNetwork(option network.CreateOptions) error {
 	// 利用Network关联容器
 	// 每次创建自身网络时，先删除掉，最后再统一将关联和自身加入进来
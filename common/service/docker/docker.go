@@ -20,6 +20,7 @@ var (
 	QueueDockerProgressMessage      = make(chan *Progress, 999)
 	QueueDockerImageDownloadMessage = make(chan map[string]*ProgressDownloadImage, 999)
 	QueueDockerComposeMessage       = make(chan string, 999)
+	QueueContainerBatchMessage      = make(chan *ProgressContainerBatch, 999)
 	BuilderAuthor                   = "DPanel"
 	BuildDesc                       = "DPanel is a docker web management panel"
 	BuildWebSite                    = "https://github.com/donknap/dpanel"
@@ -59,3 +59,13 @@ type ProgressDownloadImage struct {
 	Downloading float64 `json:"downloading"`
 	Extracting  float64 `json:"extracting"`
 }
+
+type ProgressContainerBatch struct {
+	TaskId  string `json:"taskId"`
+	Total   int    `json:"total"`
+	Done    int    `json:"done"`
+	Md5     string `json:"md5"`
+	Name    string `json:"name"`
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
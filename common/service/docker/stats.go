@@ -0,0 +1,166 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/docker/docker/api/types/container"
+	"io"
+	"log/slog"
+	"time"
+)
+
+type ContainerStatsResult struct {
+	Id        string        `json:"id"`
+	Name      string        `json:"name"`
+	Cpu       float64       `json:"cpu"`       // 占用百分比，多核时可能超过 100
+	OnlineCpu uint32        `json:"onlineCpu"` // 可用核心数
+	Memory    StatsMemory   `json:"memory"`
+	NetworkIO StatsIoResult `json:"networkIO"`
+	BlockIO   StatsIoResult `json:"blockIO"`
+	Pids      uint64        `json:"pids"`
+	ReadAt    time.Time     `json:"readAt"`
+}
+
+type StatsMemory struct {
+	Usage   uint64  `json:"usage"`
+	Limit   uint64  `json:"limit"`
+	Percent float64 `json:"percent"`
+}
+
+type StatsIoResult struct {
+	In      uint64  `json:"in"`
+	Out     uint64  `json:"out"`
+	InRate  float64 `json:"inRate"` // 字节/秒
+	OutRate float64 `json:"outRate"`
+}
+
+// ContainerStatsOnce 获取一次容器状态，cpu 使用率由 docker 返回的 precpu 计算，不包含 io 速率
+func (self Builder) ContainerStatsOnce(ctx context.Context, md5 string) (*ContainerStatsResult, error) {
+	response, err := self.Client.ContainerStats(ctx, md5, false)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	stats := &container.StatsResponse{}
+	err = json.NewDecoder(response.Body).Decode(stats)
+	if err != nil {
+		return nil, err
+	}
+	return self.ParseContainerStats(stats, nil), nil
+}
+
+// ContainerStatsStream 持续获取容器状态，ctx 取消或是容器停止后关闭 chan
+func (self Builder) ContainerStatsStream(ctx context.Context, md5 string) (<-chan *ContainerStatsResult, error) {
+	response, err := self.Client.ContainerStats(ctx, md5, true)
+	if err != nil {
+		return nil, err
+	}
+	statsChan := make(chan *ContainerStatsResult)
+	go func() {
+		defer close(statsChan)
+		defer response.Body.Close()
+
+		decoder := json.NewDecoder(response.Body)
+		var prev *container.StatsResponse
+		for {
+			stats := &container.StatsResponse{}
+			err := decoder.Decode(stats)
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					slog.Debug("container stats", "id", md5, "error", err.Error())
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case statsChan <- self.ParseContainerStats(stats, prev):
+			}
+			prev = stats
+		}
+	}()
+	return statsChan, nil
+}
+
+// ParseContainerStats 计算方式与 docker stats 命令保持一致，prev 不为空时计算 io 速率
+func (self Builder) ParseContainerStats(stats *container.StatsResponse, prev *container.StatsResponse) *ContainerStatsResult {
+	result := &ContainerStatsResult{
+		Id:     stats.ID,
+		Name:   stats.Name,
+		Pids:   stats.PidsStats.Current,
+		ReadAt: stats.Read,
+	}
+	if len(result.Name) > 0 && result.Name[0] == '/' {
+		result.Name = result.Name[1:]
+	}
+
+	onlineCpu := stats.CPUStats.OnlineCPUs
+	if onlineCpu == 0 {
+		onlineCpu = uint32(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+	result.OnlineCpu = onlineCpu
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	if cpuDelta > 0 && systemDelta > 0 {
+		result.Cpu = cpuDelta / systemDelta * float64(onlineCpu) * 100
+	}
+
+	// 内存占用需要去掉页缓存，cgroup v1 为 total_inactive_file，v2 为 inactive_file
+	usage := stats.MemoryStats.Usage
+	if v, ok := stats.MemoryStats.Stats["total_inactive_file"]; ok && v < usage {
+		usage -= v
+	} else if v, ok := stats.MemoryStats.Stats["inactive_file"]; ok && v < usage {
+		usage -= v
+	}
+	result.Memory = StatsMemory{
+		Usage: usage,
+		Limit: stats.MemoryStats.Limit,
+	}
+	if stats.MemoryStats.Limit > 0 {
+		result.Memory.Percent = float64(usage) / float64(stats.MemoryStats.Limit) * 100
+	}
+
+	result.NetworkIO = statsNetworkIo(stats)
+	result.BlockIO = statsBlockIo(stats)
+
+	if prev != nil {
+		seconds := stats.Read.Sub(prev.Read).Seconds()
+		if seconds > 0 {
+			prevNetwork, prevBlock := statsNetworkIo(prev), statsBlockIo(prev)
+			result.NetworkIO.InRate = ioRate(prevNetwork.In, result.NetworkIO.In, seconds)
+			result.NetworkIO.OutRate = ioRate(prevNetwork.Out, result.NetworkIO.Out, seconds)
+			result.BlockIO.InRate = ioRate(prevBlock.In, result.BlockIO.In, seconds)
+			result.BlockIO.OutRate = ioRate(prevBlock.Out, result.BlockIO.Out, seconds)
+		}
+	}
+	return result
+}
+
+func statsNetworkIo(stats *container.StatsResponse) StatsIoResult {
+	result := StatsIoResult{}
+	for _, item := range stats.Networks {
+		result.In += item.RxBytes
+		result.Out += item.TxBytes
+	}
+	return result
+}
+
+func statsBlockIo(stats *container.StatsResponse) StatsIoResult {
+	result := StatsIoResult{}
+	for _, item := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch item.Op {
+		case "read", "Read":
+			result.In += item.Value
+		case "write", "Write":
+			result.Out += item.Value
+		}
+	}
+	return result
+}
+
+func ioRate(prev, current uint64, seconds float64) float64 {
+	if current < prev {
+		return 0
+	}
+	return float64(current-prev) / seconds
+}
@@ -10,7 +10,7 @@ import (
 )
 
 var (
-	QueueNoticePushMessage = make(chan *entity.Notice)
+	QueueNoticePushMessage = make(chan *entity.Notice, 100)
 )
 
 const (
@@ -43,6 +43,10 @@ func (self Message) push(level string, title string, message []string) error {
 	}
 	err := dao.Notice.Create(row)
 	fmt.Printf("协程数，%v \n", runtime.NumGoroutine())
-	QueueNoticePushMessage <- row
+	// 消息已经入库，没有 ws 连接消费时不阻塞后台任务
+	select {
+	case QueueNoticePushMessage <- row:
+	default:
+	}
 	return err
 }
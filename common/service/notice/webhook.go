@@ -0,0 +1,32 @@
+package notice
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Webhook struct {
+}
+
+// Send 以 json 格式推送消息到外部地址
+func (self Webhook) Send(url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{
+		Timeout: time.Second * 10,
+	}
+	response, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s response status %d", url, response.StatusCode)
+	}
+	return nil
+}
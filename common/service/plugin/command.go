@@ -1,10 +1,18 @@
 package plugin
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/donknap/dpanel/common/function"
 	"github.com/donknap/dpanel/common/service/docker"
+	"io"
 	"log/slog"
+	"math"
+	"strings"
+	"time"
 )
 
 type Command struct {
@@ -54,3 +62,109 @@ func (self Command) Clean(str []byte) string {
 	})
 	return string(out)
 }
+
+// 单个输出流最多保留的内容，超出部分丢弃
+const execMaxOutputSize = 256 * 1024
+
+type ExecOption struct {
+	ContainerName string
+	Cmd           []string
+	Env           []string
+	WorkDir       string
+	User          string
+	Timeout       time.Duration // 为 0 时不限制
+	Output        io.Writer     // 可选，按输出顺序同时写入 stdout 与 stderr
+}
+
+type ExecResult struct {
+	Stdout    string  `json:"stdout"`
+	Stderr    string  `json:"stderr"`
+	ExitCode  int     `json:"exitCode"`
+	Duration  float64 `json:"duration"`
+	TimedOut  bool    `json:"timedOut"`
+	Truncated bool    `json:"truncated"`
+}
+
+// Exec 执行一条命令，分别收集 stdout 与 stderr 并返回退出码
+// 超时后断开连接返回，容器中的进程不会被结束
+func (self Command) Exec(ctx context.Context, option *ExecOption) (*ExecResult, error) {
+	if option.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, option.Timeout)
+		defer cancel()
+	}
+	startAt := time.Now()
+	exec, err := docker.Sdk.Client.ContainerExecCreate(ctx, option.ContainerName, container.ExecOptions{
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          option.Cmd,
+		Env:          option.Env,
+		WorkingDir:   option.WorkDir,
+		User:         option.User,
+	})
+	if err != nil {
+		return nil, err
+	}
+	conn, err := docker.Sdk.Client.ContainerExecAttach(ctx, exec.ID, container.ExecStartOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	copyDone := make(chan struct{})
+	defer close(copyDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-copyDone:
+		}
+	}()
+
+	stdout, stderr := &execOutput{}, &execOutput{}
+	var stdoutWriter, stderrWriter io.Writer = stdout, stderr
+	if option.Output != nil {
+		stdoutWriter, stderrWriter = io.MultiWriter(stdout, option.Output), io.MultiWriter(stderr, option.Output)
+	}
+	_, err = stdcopy.StdCopy(stdoutWriter, stderrWriter, conn.Reader)
+	result := &ExecResult{
+		Stdout:    strings.ToValidUTF8(stdout.buffer.String(), "�"),
+		Stderr:    strings.ToValidUTF8(stderr.buffer.String(), "�"),
+		ExitCode:  -1,
+		Truncated: stdout.truncated || stderr.truncated,
+		Duration:  math.Round(time.Since(startAt).Seconds()*1000) / 1000,
+	}
+	if ctx.Err() != nil {
+		result.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+	// 输出流结束时进程可能还没有完全退出
+	for i := 0; i < 10; i++ {
+		info, err := docker.Sdk.Client.ContainerExecInspect(ctx, exec.ID)
+		if err != nil {
+			return result, err
+		}
+		if !info.Running {
+			result.ExitCode = info.ExitCode
+			break
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	return result, nil
+}
+
+type execOutput struct {
+	buffer    bytes.Buffer
+	truncated bool
+}
+
+func (self *execOutput) Write(p []byte) (int, error) {
+	if remain := execMaxOutputSize - self.buffer.Len(); remain < len(p) {
+		self.truncated = true
+		self.buffer.Write(p[:max(remain, 0)])
+		return len(p), nil
+	}
+	return self.buffer.Write(p)
+}
@@ -0,0 +1,306 @@
+package procfs
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	// Collector 默认读取面板所在环境的 /proc，面板运行在容器中时可通过 HOST_PROC_PATH 指定挂载进来的宿主机 /proc
+	Collector                = NewCollector(os.Getenv("HOST_PROC_PATH"))
+	QueueHostMetricsMessage  = make(chan *Metrics, 1)
+	sampleInterval           = time.Millisecond * 500
+	excludeNetworkInterfaces = []string{"lo"}
+)
+
+func NewCollector(procPath string) *collector {
+	o := &collector{
+		procPath: "/proc",
+	}
+	if procPath != "" {
+		o.procPath = procPath
+		o.isHostProc = true
+	}
+	return o
+}
+
+type collector struct {
+	procPath   string
+	isHostProc bool
+	lock       sync.Mutex
+	lastCpu    map[string]cpuTime
+	lastNet    map[string]netDev
+	lastAt     time.Time
+}
+
+type cpuTime struct {
+	total uint64
+	idle  uint64
+}
+
+type netDev struct {
+	rx uint64
+	tx uint64
+}
+
+// Collect 采集主机指标，cpu 及网络速率需要两次采样计算，首次调用时会间隔 sampleInterval 采样两次
+func (self *collector) Collect(dockerRootDir string) (*Metrics, error) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	if self.lastCpu == nil {
+		if err := self.sample(); err != nil {
+			return nil, err
+		}
+		time.Sleep(sampleInterval)
+	}
+	lastCpu, lastNet, lastAt := self.lastCpu, self.lastNet, self.lastAt
+	if err := self.sample(); err != nil {
+		return nil, err
+	}
+	seconds := self.lastAt.Sub(lastAt).Seconds()
+
+	result := &Metrics{
+		CpuCore:   make([]float64, 0),
+		Network:   make([]NetworkUsage, 0),
+		CollectAt: self.lastAt,
+	}
+	result.Cpu = cpuUsage(lastCpu["cpu"], self.lastCpu["cpu"])
+	for i := 0; ; i++ {
+		current, ok := self.lastCpu["cpu"+strconv.Itoa(i)]
+		if !ok {
+			break
+		}
+		result.CpuCore = append(result.CpuCore, cpuUsage(lastCpu["cpu"+strconv.Itoa(i)], current))
+	}
+	for name, current := range self.lastNet {
+		item := NetworkUsage{
+			Name:    name,
+			RxBytes: current.rx,
+			TxBytes: current.tx,
+		}
+		if prev, ok := lastNet[name]; ok && seconds > 0 && current.rx >= prev.rx && current.tx >= prev.tx {
+			item.RxRate = float64(current.rx-prev.rx) / seconds
+			item.TxRate = float64(current.tx-prev.tx) / seconds
+		}
+		result.Network = append(result.Network, item)
+	}
+
+	var err error
+	if result.Load, err = self.loadAvg(); err != nil {
+		return nil, err
+	}
+	if result.Memory, result.Swap, err = self.memInfo(); err != nil {
+		return nil, err
+	}
+	if result.Uptime, err = self.uptime(); err != nil {
+		return nil, err
+	}
+	if dockerRootDir != "" {
+		result.Disk, _ = self.diskUsage(dockerRootDir)
+	}
+	return result, nil
+}
+
+func (self *collector) sample() error {
+	cpu, err := self.cpuStat()
+	if err != nil {
+		return err
+	}
+	net, err := self.netDev()
+	if err != nil {
+		return err
+	}
+	self.lastCpu = cpu
+	self.lastNet = net
+	self.lastAt = time.Now()
+	return nil
+}
+
+func (self *collector) path(name ...string) string {
+	return filepath.Join(append([]string{self.procPath}, name...)...)
+}
+
+func (self *collector) cpuStat() (map[string]cpuTime, error) {
+	file, err := os.Open(self.path("stat"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := make(map[string]cpuTime)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+		item := cpuTime{}
+		// user nice system idle iowait irq softirq steal，guest 已包含在 user 中不重复计算
+		for i, value := range fields[1:] {
+			if i >= 8 {
+				break
+			}
+			v, _ := strconv.ParseUint(value, 10, 64)
+			item.total += v
+			if i == 3 || i == 4 {
+				item.idle += v
+			}
+		}
+		result[fields[0]] = item
+	}
+	return result, scanner.Err()
+}
+
+// netDev 读取网卡流量
+// 挂载进来的 /proc/net 指向的是面板进程自己的网络命名空间，宿主机的网卡需要通过 1 号进程读取
+func (self *collector) netDev() (map[string]netDev, error) {
+	path := self.path("net", "dev")
+	if self.isHostProc {
+		path = self.path("1", "net", "dev")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := make(map[string]netDev)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.Contains(line, ":") {
+			continue
+		}
+		temp := strings.SplitN(line, ":", 2)
+		name := strings.TrimSpace(temp[0])
+		fields := strings.Fields(temp[1])
+		if len(fields) < 9 || excludeInterface(name) {
+			continue
+		}
+		rx, _ := strconv.ParseUint(fields[0], 10, 64)
+		tx, _ := strconv.ParseUint(fields[8], 10, 64)
+		result[name] = netDev{
+			rx: rx,
+			tx: tx,
+		}
+	}
+	return result, scanner.Err()
+}
+
+func (self *collector) loadAvg() (LoadAvg, error) {
+	result := LoadAvg{}
+	content, err := os.ReadFile(self.path("loadavg"))
+	if err != nil {
+		return result, err
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) < 3 {
+		return result, nil
+	}
+	result.Load1, _ = strconv.ParseFloat(fields[0], 64)
+	result.Load5, _ = strconv.ParseFloat(fields[1], 64)
+	result.Load15, _ = strconv.ParseFloat(fields[2], 64)
+	return result, nil
+}
+
+func (self *collector) memInfo() (memory MemoryUsage, swap MemoryUsage, err error) {
+	file, err := os.Open(self.path("meminfo"))
+	if err != nil {
+		return memory, swap, err
+	}
+	defer file.Close()
+
+	info := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		// meminfo 中的单位为 kB
+		v, _ := strconv.ParseUint(fields[1], 10, 64)
+		info[strings.TrimSuffix(fields[0], ":")] = v * 1024
+	}
+	memory.Total = info["MemTotal"]
+	if available, ok := info["MemAvailable"]; ok {
+		memory.Free = available
+	} else {
+		memory.Free = info["MemFree"] + info["Buffers"] + info["Cached"]
+	}
+	memory.Used = memory.Total - memory.Free
+	memory.Percent = percent(memory.Used, memory.Total)
+
+	swap.Total = info["SwapTotal"]
+	swap.Free = info["SwapFree"]
+	swap.Used = swap.Total - swap.Free
+	swap.Percent = percent(swap.Used, swap.Total)
+	return memory, swap, scanner.Err()
+}
+
+func (self *collector) uptime() (float64, error) {
+	content, err := os.ReadFile(self.path("uptime"))
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseFloat(fields[0], 64)
+}
+
+// diskUsage 统计 docker 数据目录所在分区的占用
+// 读取宿主机 /proc 时，通过 1 号进程的根目录访问宿主机上的路径
+func (self *collector) diskUsage(path string) (*DiskUsage, error) {
+	if self.isHostProc {
+		path = self.path("1", "root", path)
+	}
+	stat := syscall.Statfs_t{}
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return nil, err
+	}
+	result := &DiskUsage{
+		Path:  path,
+		Total: stat.Blocks * uint64(stat.Bsize),
+		Free:  stat.Bavail * uint64(stat.Bsize),
+	}
+	result.Used = result.Total - stat.Bfree*uint64(stat.Bsize)
+	result.Percent = percent(result.Used, result.Used+result.Free)
+	return result, nil
+}
+
+func cpuUsage(prev, current cpuTime) float64 {
+	if current.total <= prev.total {
+		return 0
+	}
+	total := current.total - prev.total
+	idle := current.idle - prev.idle
+	if idle > total {
+		return 0
+	}
+	return float64(total-idle) / float64(total) * 100
+}
+
+func percent(used, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(used) / float64(total) * 100
+}
+
+func excludeInterface(name string) bool {
+	for _, item := range excludeNetworkInterfaces {
+		if item == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package procfs
+
+import "time"
+
+type Metrics struct {
+	Load      LoadAvg        `json:"load"`
+	Cpu       float64        `json:"cpu"`     // 总使用率
+	CpuCore   []float64      `json:"cpuCore"` // 每个核心的使用率
+	Memory    MemoryUsage    `json:"memory"`
+	Swap      MemoryUsage    `json:"swap"`
+	Disk      *DiskUsage     `json:"disk"` // docker 数据目录所在分区
+	Network   []NetworkUsage `json:"network"`
+	Uptime    float64        `json:"uptime"` // 秒
+	CollectAt time.Time      `json:"collectAt"`
+}
+
+type LoadAvg struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+type MemoryUsage struct {
+	Total   uint64  `json:"total"`
+	Used    uint64  `json:"used"`
+	Free    uint64  `json:"free"`
+	Percent float64 `json:"percent"`
+}
+
+type DiskUsage struct {
+	Path    string  `json:"path"`
+	Total   uint64  `json:"total"`
+	Used    uint64  `json:"used"`
+	Free    uint64  `json:"free"`
+	Percent float64 `json:"percent"`
+}
+
+type NetworkUsage struct {
+	Name    string  `json:"name"`
+	RxBytes uint64  `json:"rxBytes"`
+	TxBytes uint64  `json:"txBytes"`
+	RxRate  float64 `json:"rxRate"` // 字节/秒
+	TxRate  float64 `json:"txRate"`
+}
@@ -0,0 +1,9 @@
+package storage
+
+import (
+	"path/filepath"
+)
+
+func (self Local) GetConsoleRecordPath(name string) string {
+	return filepath.Join(self.GetStorageLocalPath(), "console", name)
+}
@@ -0,0 +1,9 @@
+package storage
+
+import (
+	"path/filepath"
+)
+
+func (self Local) GetLogArchivePath(name string) string {
+	return filepath.Join(self.GetStorageLocalPath(), "log", name)
+}
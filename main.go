@@ -87,6 +87,17 @@ func main() {
 			&entity.SiteDomain{},
 			&entity.Compose{},
 			&entity.Backup{},
+			&entity.AlertRule{},
+			&entity.DiskUsage{},
+			&entity.ContainerStat{},
+			&entity.ImageUpdate{},
+			&entity.SiteRevision{},
+			&entity.ContainerLog{},
+			&entity.LogArchive{},
+			&entity.ConsoleRecord{},
+			&entity.Cron{},
+			&entity.CronLog{},
+			&entity.ContainerExec{},
 		)
 		if err != nil {
 			panic(err)